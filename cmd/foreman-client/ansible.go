@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+// ansibleOptions contains the flags of the ansible command
+type ansibleOptions struct {
+	hostgroup string
+	roles     []string
+	variable  string
+	match     string
+	value     string
+}
+
+// runAnsible lists, assigns or plays the ansible roles of a host or hostgroup, or overrides an ansible variable
+func runAnsible(ctx context.Context, connection *foreman.ConnectionInfo, opts *options) {
+
+	if opts.action == "variable" {
+		id, err := connection.ResolveAnsibleVariable(ctx, opts.ansible.variable)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		value, err := connection.SetAnsibleOverrideValue(ctx, id, opts.ansible.match, opts.ansible.value)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		log.Printf("Response: %s is %v for %s", opts.ansible.variable, value.Value, value.Match)
+		return
+	}
+
+	var hostgroupID int
+	var err error
+	if opts.ansible.hostgroup != "" {
+		hostgroupID, err = connection.ResolveHostgroup(ctx, opts.ansible.hostgroup)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+	}
+
+	switch opts.action {
+	case "roles":
+		var roles []foreman.AnsibleRole
+		if hostgroupID != 0 {
+			roles, err = connection.ListHostgroupAnsibleRoles(ctx, hostgroupID)
+		} else {
+			roles, err = connection.ListHostAnsibleRoles(ctx)
+		}
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		for _, r := range roles {
+			fmt.Println(r.Name)
+		}
+	case "assign":
+		var roleIDs []int
+		roleIDs, err = resolveAll(ctx, opts.ansible.roles, connection.ResolveAnsibleRole)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		if hostgroupID != 0 {
+			err = connection.AssignHostgroupAnsibleRoles(ctx, hostgroupID, roleIDs)
+		} else {
+			err = connection.AssignHostAnsibleRoles(ctx, roleIDs)
+		}
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		log.Printf("Response: Ansible roles %v were assigned", opts.ansible.roles)
+	case "play":
+		var job *foreman.JobInvocation
+		if hostgroupID != 0 {
+			job, err = connection.PlayHostgroupAnsibleRoles(ctx, hostgroupID)
+		} else {
+			job, err = connection.PlayHostAnsibleRoles(ctx)
+		}
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		log.Printf("Response: Job invocation %d started to play the ansible roles", job.ID)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+// auditOptions contains the flags of the audit command
+type auditOptions struct {
+	since  time.Duration
+	user   string
+	action string
+}
+
+// runAudit prints a timeline of the changes matching the host, user and action provided
+func runAudit(ctx context.Context, connection *foreman.ConnectionInfo, opts *options) {
+
+	filter := foreman.AuditFilter{
+		Host:   connection.Hostname,
+		User:   opts.audit.user,
+		Action: opts.audit.action,
+		Since:  time.Now().Add(-opts.audit.since),
+		Query:  opts.search,
+	}
+
+	audits, err := connection.FilterAudits(ctx, filter)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+
+	sort.Slice(audits, func(i, j int) bool {
+		ti, _ := audits[i].CreatedTime()
+		tj, _ := audits[j].CreatedTime()
+		return ti.Before(tj)
+	})
+
+	for _, a := range audits {
+		fmt.Printf("%s  %-8s %s %s [%s] by %s", a.CreatedAt, a.Action, a.AuditableType, a.AuditableName, a.RemoteAddress, a.UserName)
+		fields := make([]string, 0, len(a.AuditedChanges))
+		for field := range a.AuditedChanges {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			fmt.Printf("\n    %s: %v", field, a.AuditedChanges[field])
+		}
+		fmt.Println()
+	}
+
+	log.Printf("Response: %d changes found since %s", len(audits), filter.Since.Format(time.RFC3339))
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+// bulkOptions contains the flags of the bulk command
+type bulkOptions struct {
+	hostgroup   string
+	owner       string
+	environment string
+	power       string
+	reboot      bool
+	confirm     bool
+}
+
+// runBulk applies a bulk action to every host matching the search and prints the result of each host
+func runBulk(ctx context.Context, connection *foreman.ConnectionInfo, opts *options) {
+
+	var results []foreman.BulkHostResult
+	var err error
+
+	switch opts.action {
+	case "destroy":
+		results, err = connection.BulkDestroyHosts(ctx, opts.search)
+	case "hostgroup":
+		var id int
+		id, err = connection.ResolveHostgroup(ctx, opts.bulk.hostgroup)
+		if err != nil {
+			break
+		}
+		results, err = connection.BulkReassignHostgroup(ctx, opts.search, id)
+	case "owner":
+		var id int
+		id, err = connection.ResolveUser(ctx, opts.bulk.owner)
+		if err != nil {
+			break
+		}
+		results, err = connection.BulkChangeOwner(ctx, opts.search, id, "User")
+	case "environment":
+		var id int
+		id, err = connection.ResolveEnvironment(ctx, opts.bulk.environment)
+		if err != nil {
+			break
+		}
+		results, err = connection.BulkChangeEnvironment(ctx, opts.search, id)
+	case "build":
+		results, err = connection.BulkBuild(ctx, opts.search, opts.bulk.reboot)
+	case "power":
+		results, err = connection.BulkPower(ctx, opts.search, opts.bulk.power)
+	}
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+
+	failed := 0
+	for _, r := range results {
+		status := "ok"
+		if !r.Success {
+			status = "failed"
+			failed++
+		}
+		fmt.Printf("%-6d %-40s %s\n", r.ID, r.Name, status)
+	}
+
+	if failed > 0 {
+		log.Fatalf("Error: bulk %s failed on %d of %d hosts", opts.action, failed, len(results))
+	}
+	log.Printf("Response: bulk %s succeeded on %d hosts", opts.action, len(results))
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+// factsOptions contains the flags of the facts command
+type factsOptions struct {
+	fact  string
+	value string
+}
+
+// runFacts prints the facts of a host, the fact values matching a search or the hosts matching a fact as json
+func runFacts(ctx context.Context, connection *foreman.ConnectionInfo, opts *options) {
+
+	var result interface{}
+	var err error
+
+	switch {
+	case opts.facts.fact != "":
+		result, err = connection.SearchHostsByFact(ctx, opts.facts.fact, opts.facts.value)
+	case connection.Hostname != "":
+		result, err = connection.GetHostFacts(ctx)
+	default:
+		result, err = connection.ListFactValues(ctx, opts.search)
+	}
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+
+	printJSON(result)
+}
+
+// printJSON writes the value provided to stdout as indented json
+func printJSON(v interface{}) {
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "    ")
+	err := enc.Encode(v)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+}
@@ -0,0 +1,1002 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	// createUsage message identify what input is expected
+	createUsage = `
+	##############################################################################################
+	#                                                                                            #
+	#  Enter the name and size of the new host you would like to create                          #
+	#                                                                                            #
+	#  Usage:                                                                                    #
+	#      ./foreman-client create -name=mytestenv -size=i3.4xlarge -group=1 -profile=2          #
+	#                                                                                            #
+	#  Optional:                                                                                 #
+	#      -os="CentOS 7.9" -arch=x86_64 -medium=CentOS-mirror -ptable="Kickstart default"       #
+	#                                                                                            #
+	##############################################################################################
+	`
+
+	// deleteUsage message identify what input is expected
+	deleteUsage = `
+	########################################################################
+	#                                                                      #
+	#  Enter the name of the host you would like to delete                 #
+	#                                                                      #
+	#  Usage:                                                              #
+	#      ./foreman-client delete -name=dev99                             #
+	#      ./foreman-client delete -mac=52:54:00:12:34:56                  #
+	#                                                                      #
+	########################################################################
+	`
+
+	// templateUsage message identify what input is expected
+	templateUsage = `
+	##############################################################################################
+	#                                                                                            #
+	#  Enter the name of the provisioning template you would like to show, diff or render        #
+	#                                                                                            #
+	#  Usage:                                                                                    #
+	#      ./foreman-client template -name="Kickstart default"                                   #
+	#      ./foreman-client template -name="Kickstart default" -file=kickstart.erb               #
+	#      ./foreman-client template -host=dev99 -kind=provision                                 #
+	#      ./foreman-client template -mac=52:54:00:12:34:56 -kind=provision                      #
+	#                                                                                            #
+	##############################################################################################
+	`
+
+	// rebuildUsage message identify what input is expected
+	rebuildUsage = `
+	########################################################################
+	#                                                                      #
+	#  Enter the name of the host you would like to rebuild                #
+	#                                                                      #
+	#  Usage:                                                              #
+	#      ./foreman-client rebuild -name=dev99 -powercycle                #
+	#      ./foreman-client rebuild -name=dev99 -cancel                    #
+	#      ./foreman-client rebuild -ip=10.0.0.15 -powercycle              #
+	#                                                                      #
+	########################################################################
+	`
+
+	// factsUsage message identify what input is expected
+	factsUsage = `
+	##############################################################################################
+	#                                                                                            #
+	#  Enter the host, search or fact you would like to retrieve facts for                       #
+	#                                                                                            #
+	#  Usage:                                                                                    #
+	#      ./foreman-client facts -name=dev99                                                    #
+	#      ./foreman-client facts -ip=10.0.0.15                                                  #
+	#      ./foreman-client facts -search="fact = os::family and value = RedHat"                 #
+	#      ./foreman-client facts -fact=os::family -value=RedHat                                 #
+	#                                                                                            #
+	##############################################################################################
+	`
+
+	// reportUsage message identify what input is expected
+	reportUsage = `
+	########################################################################
+	#                                                                      #
+	#  Enter the name of the host you would like to see the last report of #
+	#                                                                      #
+	#  Usage:                                                              #
+	#      ./foreman-client report -name=dev99 -outofsync=35m              #
+	#                                                                      #
+	########################################################################
+	`
+
+	// runUsage message identify what input is expected
+	runUsage = `
+	##############################################################################################
+	#                                                                                            #
+	#  Enter the search query, job template and inputs of the command you would like to run      #
+	#                                                                                            #
+	#  Usage:                                                                                    #
+	#      ./foreman-client run -search="name ~ dev" -template="Run Command - SSH Default" \      #
+	#          -input command="uptime"                                                           #
+	#                                                                                            #
+	##############################################################################################
+	`
+
+	// auditUsage message identify what input is expected
+	auditUsage = `
+	########################################################################
+	#                                                                      #
+	#  Enter the name of the host you would like to see the changes of     #
+	#                                                                      #
+	#  Usage:                                                              #
+	#      ./foreman-client audit -name=dev99 -since=24h                   #
+	#      ./foreman-client audit -id=42 -since=24h                        #
+	#      ./foreman-client audit -user=admin -action=destroy -since=72h   #
+	#                                                                      #
+	########################################################################
+	`
+
+	// userUsage message identify what input is expected
+	userUsage = `
+	##############################################################################################
+	#                                                                                            #
+	#  Enter the user, usergroup or role you would like to create, update or inspect             #
+	#                                                                                            #
+	#  Usage:                                                                                    #
+	#      ./foreman-client user -login=jdoe -mail=jdoe@example.com -roles=Viewer -orgs=ACME     #
+	#      ./foreman-client usergroup -name=devs -users=jdoe -external=cn=devs -authsource=2     #
+	#      ./foreman-client permissions -role=Viewer                                             #
+	#      ./foreman-client permissions -resource=Host -bookmark="edit permissions"              #
+	#                                                                                            #
+	##############################################################################################
+	`
+
+	// tokenUsage message identify what input is expected
+	tokenUsage = `
+	##############################################################################################
+	#                                                                                            #
+	#  Enter the action and user of the personal access token you would like to manage           #
+	#                                                                                            #
+	#  Usage:                                                                                    #
+	#      ./foreman-client token create -user=jenkins -name=ci-2020-03 -expires=720h            #
+	#      ./foreman-client token list -user=jenkins                                             #
+	#      ./foreman-client token list -user=jenkins -bookmark="active tokens"                   #
+	#      ./foreman-client token revoke -user=jenkins -id=12                                    #
+	#                                                                                            #
+	##############################################################################################
+	`
+
+	// puppetUsage message identify what input is expected
+	puppetUsage = `
+	##############################################################################################
+	#                                                                                            #
+	#  Enter the host whose ENC you would like to view or the class parameter to override        #
+	#                                                                                            #
+	#  Usage:                                                                                    #
+	#      ./foreman-client enc -name=dev99                                                      #
+	#      ./foreman-client override -class=apache -param=port -match=fqdn=dev99 -value=8080     #
+	#                                                                                            #
+	##############################################################################################
+	`
+
+	// ansibleUsage message identify what input is expected
+	ansibleUsage = `
+	##############################################################################################
+	#                                                                                            #
+	#  Enter the action and host or hostgroup of the ansible roles you would like to manage      #
+	#                                                                                            #
+	#  Usage:                                                                                    #
+	#      ./foreman-client ansible roles -name=dev99                                            #
+	#      ./foreman-client ansible assign -hostgroup=web -roles=nginx,certbot                   #
+	#      ./foreman-client ansible play -name=dev99                                             #
+	#      ./foreman-client ansible play -mac=52:54:00:12:34:56                                  #
+	#      ./foreman-client ansible variable -variable=nginx_port -match=fqdn=dev99 -value=8080  #
+	#                                                                                            #
+	##############################################################################################
+	`
+
+	// bulkUsage message identify what input is expected
+	bulkUsage = `
+	##############################################################################################
+	#                                                                                            #
+	#  Enter the bulk action and search query of the hosts you would like to change              #
+	#                                                                                            #
+	#  Usage:                                                                                    #
+	#      ./foreman-client bulk destroy -search="name ~ dev" -confirm                           #
+	#      ./foreman-client bulk hostgroup -search="name ~ dev" -hostgroup=web                   #
+	#      ./foreman-client bulk owner -search="name ~ dev" -owner=jdoe                          #
+	#      ./foreman-client bulk environment -search="name ~ dev" -environment=production        #
+	#      ./foreman-client bulk build -search="name ~ dev" -reboot                              #
+	#      ./foreman-client bulk power -search="name ~ dev" -power=cycle                         #
+	#      ./foreman-client bulk build -bookmark="dev hosts"                                     #
+	#                                                                                            #
+	##############################################################################################
+	`
+
+	// settingsUsage message identify what input is expected
+	settingsUsage = `
+	##############################################################################################
+	#                                                                                            #
+	#  Enter the action and name of the setting you would like to read, update or check          #
+	#                                                                                            #
+	#  Usage:                                                                                    #
+	#      ./foreman-client settings get -name=destroy_vm_on_host_delete                         #
+	#      ./foreman-client settings get -bookmark="puppet settings"                             #
+	#      ./foreman-client settings set -name=token_duration -value=360                         #
+	#      ./foreman-client settings check -file=settings.json                                   #
+	#                                                                                            #
+	##############################################################################################
+	`
+
+	createArg   = "create"
+	deleteArg   = "delete"
+	templateArg = "template"
+	rebuildArg  = "rebuild"
+	factsArg    = "facts"
+	reportArg   = "report"
+	runArg      = "run"
+	auditArg    = "audit"
+	userArg     = "user"
+	groupArg    = "usergroup"
+	permArg     = "permissions"
+	tokenArg    = "token"
+	listArg     = "list"
+	revokeArg   = "revoke"
+	encArg      = "enc"
+	overrideArg = "override"
+	ansibleArg  = "ansible"
+	rolesArg    = "roles"
+	assignArg   = "assign"
+	playArg     = "play"
+	variableArg = "variable"
+	bulkArg     = "bulk"
+	settingsArg = "settings"
+)
+
+// options contains the sub command of the command line and the flags of each sub command,
+// the host and the create flags are set on the connection as the host calls of the library read them from there
+type options struct {
+	command string
+	action  string
+
+	// host is the id, mac or ip of the host, looked up with GetHostBy before the command runs
+	host string
+
+	search             string
+	bookmark           string
+	bookmarkController string
+
+	template    templateOptions
+	rebuild     rebuildOptions
+	facts       factsOptions
+	report      reportOptions
+	run         runOptions
+	audit       auditOptions
+	user        userOptions
+	usergroup   usergroupOptions
+	permissions permissionsOptions
+	token       tokenOptions
+	override    overrideOptions
+	ansible     ansibleOptions
+	bulk        bulkOptions
+	settings    settingsOptions
+}
+
+// usage prints the usage of every sub command
+func usage() {
+	log.Print(createUsage)
+	log.Print(deleteUsage)
+	log.Print(templateUsage)
+	log.Print(rebuildUsage)
+	log.Print(factsUsage)
+	log.Print(reportUsage)
+	log.Print(runUsage)
+	log.Print(auditUsage)
+	log.Print(userUsage)
+	log.Print(tokenUsage)
+	log.Print(puppetUsage)
+	log.Print(ansibleUsage)
+	log.Print(bulkUsage)
+	log.Print(settingsUsage)
+}
+
+// logerr error check and logging
+func logerr(err error) {
+	if err != nil {
+		log.Printf("Write failed: %v", err)
+	}
+}
+
+// splitList splits a comma separated flag value, ignoring empty entries
+func splitList(value string) []string {
+	var list []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// countSet returns how many of the flags provided are set
+func countSet(flags ...bool) int {
+	n := 0
+	for _, set := range flags {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// inputsFlag collects repeated key=value flags
+type inputsFlag map[string]string
+
+// String implements the flag.Value interface
+func (i inputsFlag) String() string {
+	var pairs []string
+	for k, v := range i {
+		pairs = append(pairs, k+"="+v)
+	}
+	return strings.Join(pairs, ",")
+}
+
+// Set implements the flag.Value interface
+func (i inputsFlag) Set(value string) error {
+	kv := strings.SplitN(value, "=", 2)
+	if len(kv) != 2 || kv[0] == "" {
+		return errors.New("input needs to be in the form key=value")
+	}
+	i[kv[0]] = kv[1]
+	return nil
+}
+
+// setHost sets the host of the command from exactly one of the name, id, mac or ip flags,
+// hosts selected by id, mac or ip are looked up with GetHostBy before the command runs
+func (o *options) setHost(ci *foreman.ConnectionInfo, name string, id int, mac string, ip string) error {
+
+	var selectors []string
+	if id != 0 {
+		selectors = append(selectors, strconv.Itoa(id))
+	}
+	for _, s := range []string{mac, ip} {
+		if s != "" {
+			selectors = append(selectors, s)
+		}
+	}
+
+	switch {
+	case name == "" && len(selectors) == 0:
+		return errors.New("hostname needs to be provided")
+	case name != "" && len(selectors) > 0, len(selectors) > 1:
+		return errors.New("only one of name, id, mac or ip can be provided")
+	case name != "":
+		ci.Hostname = name
+		return nil
+	}
+
+	// dotted macs are refused as GetHostBy would look them up as a name
+	if _, err := net.ParseMAC(mac); mac != "" && (err != nil || !strings.ContainsAny(mac, ":-")) {
+		return errors.New("mac [" + mac + "] is not a valid mac address")
+	}
+	if ip != "" && net.ParseIP(ip) == nil {
+		return errors.New("ip [" + ip + "] is not a valid ip address")
+	}
+	o.host = selectors[0]
+
+	return nil
+}
+
+// setOptionalHost sets the host of the command like setHost when any of the name, id, mac or ip flags is provided
+// and reports whether one was
+func (o *options) setOptionalHost(ci *foreman.ConnectionInfo, name string, id int, mac string, ip string) (bool, error) {
+
+	if name == "" && id == 0 && mac == "" && ip == "" {
+		return false, nil
+	}
+
+	return true, o.setHost(ci, name, id, mac, ip)
+}
+
+// parseFlags checks the credentials of the connection and parses the sub command and flags of the command line
+func parseFlags(ci *foreman.ConnectionInfo) (*options, error) {
+
+	if ci.Username == "" || ci.Password == "" || ci.BaseURL == "" {
+		usage()
+		msg := "username, password & url should be part of the api call"
+		return nil, errors.New(msg)
+	}
+
+	if len(os.Args) < 2 {
+		usage()
+		msg := "create or delete sub command is required"
+		return nil, errors.New(msg)
+	}
+
+	o := &options{command: os.Args[1]}
+	createCommand := flag.NewFlagSet(createArg, flag.ExitOnError)
+	createNamePtr := createCommand.String("name", "", "name of instance to create. (Required)")
+	createSizePtr := createCommand.String("size", "", "size of instance to create. (Required)")
+	createHostGroupPtr := createCommand.Int("group", 0, "hostgroup_id to use. (Required)")
+	createHostProfilePtr := createCommand.String("profile", "0", "compute_profile_id to use. (Required)")
+	createOSPtr := createCommand.String("os", "", "operating system name or id to use.")
+	createArchPtr := createCommand.String("arch", "", "architecture name or id to use.")
+	createMediumPtr := createCommand.String("medium", "", "installation medium name or id to use.")
+	createPtablePtr := createCommand.String("ptable", "", "partition table name or id to use.")
+
+	deleteCommand := flag.NewFlagSet(deleteArg, flag.ExitOnError)
+	deleteNamePtr := deleteCommand.String("name", "", "name of instance to delete. (Required unless id, mac or ip is set)")
+	deleteIDPtr := deleteCommand.Int("id", 0, "id of the host, instead of name.")
+	deleteMACPtr := deleteCommand.String("mac", "", "mac address of the host, instead of name.")
+	deleteIPPtr := deleteCommand.String("ip", "", "ip address of the host, instead of name.")
+
+	templateCommand := flag.NewFlagSet(templateArg, flag.ExitOnError)
+	templateNamePtr := templateCommand.String("name", "", "name or id of the provisioning template.")
+	templateFilePtr := templateCommand.String("file", "", "local file to diff against the provisioning template.")
+	templateHostPtr := templateCommand.String("host", "", "name of host to render the template for.")
+	templateKindPtr := templateCommand.String("kind", "provision", "template kind to render for the host.")
+	templateIDPtr := templateCommand.Int("id", 0, "id of host to render the template for, instead of host.")
+	templateMACPtr := templateCommand.String("mac", "", "mac address of host to render the template for, instead of host.")
+	templateIPPtr := templateCommand.String("ip", "", "ip address of host to render the template for, instead of host.")
+
+	rebuildCommand := flag.NewFlagSet(rebuildArg, flag.ExitOnError)
+	rebuildNamePtr := rebuildCommand.String("name", "", "name of instance to rebuild. (Required unless id, mac or ip is set)")
+	rebuildIDPtr := rebuildCommand.Int("id", 0, "id of the host, instead of name.")
+	rebuildMACPtr := rebuildCommand.String("mac", "", "mac address of the host, instead of name.")
+	rebuildIPPtr := rebuildCommand.String("ip", "", "ip address of the host, instead of name.")
+	rebuildPowerCyclePtr := rebuildCommand.Bool("powercycle", false, "power cycle the instance after enabling build.")
+	rebuildCancelPtr := rebuildCommand.Bool("cancel", false, "cancel a pending build instead.")
+
+	factsCommand := flag.NewFlagSet(factsArg, flag.ExitOnError)
+	factsNamePtr := factsCommand.String("name", "", "name of host to retrieve facts for.")
+	factsIDPtr := factsCommand.Int("id", 0, "id of the host, instead of name.")
+	factsMACPtr := factsCommand.String("mac", "", "mac address of the host, instead of name.")
+	factsIPPtr := factsCommand.String("ip", "", "ip address of the host, instead of name.")
+	factsSearchPtr := factsCommand.String("search", "", "search query for fact values across hosts.")
+	factsBookmarkPtr := factsCommand.String("bookmark", "", "name of a bookmark to use as the search query.")
+	factsFactPtr := factsCommand.String("fact", "", "name of fact to search hosts by.")
+	factsValuePtr := factsCommand.String("value", "", "value of fact to search hosts by.")
+
+	reportCommand := flag.NewFlagSet(reportArg, flag.ExitOnError)
+	reportNamePtr := reportCommand.String("name", "", "name of host to show the last report of. (Required unless id, mac or ip is set)")
+	reportIDPtr := reportCommand.Int("id", 0, "id of the host, instead of name.")
+	reportMACPtr := reportCommand.String("mac", "", "mac address of the host, instead of name.")
+	reportIPPtr := reportCommand.String("ip", "", "ip address of the host, instead of name.")
+	reportOutOfSyncPtr := reportCommand.Duration("outofsync", 35*time.Minute, "age after which the last report is out of sync.")
+
+	runCommand := flag.NewFlagSet(runArg, flag.ExitOnError)
+	runSearchPtr := runCommand.String("search", "", "search query of the hosts to run on. (Required)")
+	runBookmarkPtr := runCommand.String("bookmark", "", "name of a bookmark to use as the search query.")
+	runTemplatePtr := runCommand.String("template", "", "name or id of the job template to run. (Required)")
+	runInputs := inputsFlag{}
+	runCommand.Var(runInputs, "input", "job template input in the form key=value, may be repeated.")
+
+	auditCommand := flag.NewFlagSet(auditArg, flag.ExitOnError)
+	auditNamePtr := auditCommand.String("name", "", "name of host to show the changes of, deleted hosts included.")
+	auditIDPtr := auditCommand.Int("id", 0, "id of an existing host, instead of name.")
+	auditMACPtr := auditCommand.String("mac", "", "mac address of an existing host, instead of name.")
+	auditIPPtr := auditCommand.String("ip", "", "ip address of an existing host, instead of name.")
+	auditSincePtr := auditCommand.Duration("since", 24*time.Hour, "how far back to show changes.")
+	auditUserPtr := auditCommand.String("user", "", "only show changes made by this user.")
+	auditActionPtr := auditCommand.String("action", "", "only show changes of this action (create, update, destroy).")
+	auditBookmarkPtr := auditCommand.String("bookmark", "", "name of a bookmark to add to the search query.")
+
+	userCommand := flag.NewFlagSet(userArg, flag.ExitOnError)
+	userLoginPtr := userCommand.String("login", "", "login of the user to create or update. (Required)")
+	userFirstNamePtr := userCommand.String("firstname", "", "first name of the user.")
+	userLastNamePtr := userCommand.String("lastname", "", "last name of the user.")
+	userMailPtr := userCommand.String("mail", "", "email address of the user.")
+	userAuthSourcePtr := userCommand.Int("authsource", 0, "auth_source_id to authenticate the user with.")
+	userAdminPtr := userCommand.Bool("admin", false, "make the user an administrator, -admin=false revokes it.")
+	userRolesPtr := userCommand.String("roles", "", "comma separated roles to add to the assigned roles.")
+	userOrgsPtr := userCommand.String("orgs", "", "comma separated organizations to add to the assigned organizations.")
+	userLocationsPtr := userCommand.String("locations", "", "comma separated locations to add to the assigned locations.")
+
+	groupCommand := flag.NewFlagSet(groupArg, flag.ExitOnError)
+	groupNamePtr := groupCommand.String("name", "", "name of the usergroup to create or update. (Required)")
+	groupUsersPtr := groupCommand.String("users", "", "comma separated user logins to add to the members.")
+	groupRolesPtr := groupCommand.String("roles", "", "comma separated roles to add to the assigned roles.")
+	groupExternalPtr := groupCommand.String("external", "", "external (LDAP) group to map to the usergroup.")
+	groupAuthSourcePtr := groupCommand.Int("authsource", 0, "auth_source_id of the external group.")
+	groupRefreshPtr := groupCommand.Bool("refresh", false, "refresh the members of the mapped external groups.")
+
+	permCommand := flag.NewFlagSet(permArg, flag.ExitOnError)
+	permRolePtr := permCommand.String("role", "", "name of role to list the filters of.")
+	permResourcePtr := permCommand.String("resource", "", "resource type to list the permissions of.")
+	permBookmarkPtr := permCommand.String("bookmark", "", "name of a bookmark to add to the permissions search query.")
+
+	tokenCommand := flag.NewFlagSet(tokenArg, flag.ExitOnError)
+	tokenUserPtr := tokenCommand.String("user", "", "login of the token owner, defaults to the api user.")
+	tokenNamePtr := tokenCommand.String("name", "", "name of the token to create.")
+	tokenExpiresPtr := tokenCommand.Duration("expires", 0, "lifetime of the token to create, 0 never expires.")
+	tokenIDPtr := tokenCommand.Int("id", 0, "id of the token to revoke.")
+	tokenBookmarkPtr := tokenCommand.String("bookmark", "", "name of a bookmark to use as the search query of the tokens to list.")
+
+	encCommand := flag.NewFlagSet(encArg, flag.ExitOnError)
+	encNamePtr := encCommand.String("name", "", "name of host to view the ENC of. (Required unless id, mac or ip is set)")
+	encIDPtr := encCommand.Int("id", 0, "id of the host, instead of name.")
+	encMACPtr := encCommand.String("mac", "", "mac address of the host, instead of name.")
+	encIPPtr := encCommand.String("ip", "", "ip address of the host, instead of name.")
+
+	overrideCommand := flag.NewFlagSet(overrideArg, flag.ExitOnError)
+	overrideClassPtr := overrideCommand.String("class", "", "name of the puppet class. (Required)")
+	overrideParamPtr := overrideCommand.String("param", "", "name of the smart class parameter. (Required)")
+	overrideMatchPtr := overrideCommand.String("match", "", "matcher of the override, e.g. fqdn=dev99 or hostgroup=web. (Required)")
+	overrideValuePtr := overrideCommand.String("value", "", "value of the override, the current overrides are listed when empty.")
+
+	ansibleCommand := flag.NewFlagSet(ansibleArg, flag.ExitOnError)
+	ansibleNamePtr := ansibleCommand.String("name", "", "name of the host.")
+	ansibleIDPtr := ansibleCommand.Int("id", 0, "id of the host, instead of name.")
+	ansibleMACPtr := ansibleCommand.String("mac", "", "mac address of the host, instead of name.")
+	ansibleIPPtr := ansibleCommand.String("ip", "", "ip address of the host, instead of name.")
+	ansibleHostgroupPtr := ansibleCommand.String("hostgroup", "", "name of the hostgroup.")
+	ansibleRolesPtr := ansibleCommand.String("roles", "", "comma separated ansible roles to assign.")
+	ansibleVariablePtr := ansibleCommand.String("variable", "", "name of the ansible variable.")
+	ansibleMatchPtr := ansibleCommand.String("match", "", "matcher of the override, e.g. fqdn=dev99 or hostgroup=web.")
+	ansibleValuePtr := ansibleCommand.String("value", "", "value of the override.")
+
+	bulkCommand := flag.NewFlagSet(bulkArg, flag.ExitOnError)
+	bulkSearchPtr := bulkCommand.String("search", "", "search query of the hosts to change. (Required)")
+	bulkBookmarkPtr := bulkCommand.String("bookmark", "", "name of a bookmark to use as the search query.")
+	bulkHostgroupPtr := bulkCommand.String("hostgroup", "", "hostgroup to move the hosts to.")
+	bulkOwnerPtr := bulkCommand.String("owner", "", "login of the user to own the hosts.")
+	bulkEnvironmentPtr := bulkCommand.String("environment", "", "puppet environment to set on the hosts.")
+	bulkPowerPtr := bulkCommand.String("power", "", "power action to send to the hosts.")
+	bulkRebootPtr := bulkCommand.Bool("reboot", false, "reboot the hosts after enabling build.")
+	bulkConfirmPtr := bulkCommand.Bool("confirm", false, "confirm the hosts should be destroyed.")
+
+	settingsCommand := flag.NewFlagSet(settingsArg, flag.ExitOnError)
+	settingsNamePtr := settingsCommand.String("name", "", "name of the setting, all settings are listed when empty.")
+	settingsValuePtr := settingsCommand.String("value", "", "value to set.")
+	settingsFilePtr := settingsCommand.String("file", "", "json file of setting names and expected values.")
+	settingsBookmarkPtr := settingsCommand.String("bookmark", "", "name of a bookmark to use as the search query of the settings to list.")
+
+	switch os.Args[1] {
+	case createArg:
+		err := createCommand.Parse(os.Args[2:])
+		logerr(err)
+	case deleteArg:
+		err := deleteCommand.Parse(os.Args[2:])
+		logerr(err)
+		ci.Action = deleteArg
+	case templateArg:
+		err := templateCommand.Parse(os.Args[2:])
+		logerr(err)
+	case rebuildArg:
+		err := rebuildCommand.Parse(os.Args[2:])
+		logerr(err)
+	case factsArg:
+		err := factsCommand.Parse(os.Args[2:])
+		logerr(err)
+	case reportArg:
+		err := reportCommand.Parse(os.Args[2:])
+		logerr(err)
+	case runArg:
+		err := runCommand.Parse(os.Args[2:])
+		logerr(err)
+	case auditArg:
+		err := auditCommand.Parse(os.Args[2:])
+		logerr(err)
+	case userArg:
+		err := userCommand.Parse(os.Args[2:])
+		logerr(err)
+	case groupArg:
+		err := groupCommand.Parse(os.Args[2:])
+		logerr(err)
+	case permArg:
+		err := permCommand.Parse(os.Args[2:])
+		logerr(err)
+	case tokenArg:
+		if len(os.Args) < 3 {
+			usage()
+			msg := "create, list or revoke token action is required"
+			return nil, errors.New(msg)
+		}
+		err := tokenCommand.Parse(os.Args[3:])
+		logerr(err)
+		o.action = os.Args[2]
+	case encArg:
+		err := encCommand.Parse(os.Args[2:])
+		logerr(err)
+	case overrideArg:
+		err := overrideCommand.Parse(os.Args[2:])
+		logerr(err)
+	case ansibleArg:
+		if len(os.Args) < 3 {
+			usage()
+			msg := "roles, assign, play or variable ansible action is required"
+			return nil, errors.New(msg)
+		}
+		err := ansibleCommand.Parse(os.Args[3:])
+		logerr(err)
+		o.action = os.Args[2]
+	case bulkArg:
+		if len(os.Args) < 3 {
+			usage()
+			msg := "destroy, hostgroup, owner, environment, build or power bulk action is required"
+			return nil, errors.New(msg)
+		}
+		err := bulkCommand.Parse(os.Args[3:])
+		logerr(err)
+		o.action = os.Args[2]
+	case settingsArg:
+		if len(os.Args) < 3 {
+			usage()
+			msg := "get, set or check settings action is required"
+			return nil, errors.New(msg)
+		}
+		err := settingsCommand.Parse(os.Args[3:])
+		logerr(err)
+		o.action = os.Args[2]
+	default:
+		usage()
+		os.Exit(1)
+	}
+
+	if createCommand.Parsed() {
+		if *createNamePtr == "" {
+			createCommand.PrintDefaults()
+			msg := "hostname needs to be provided"
+			return nil, errors.New(msg)
+		}
+
+		match, _ := regexp.MatchString("^[a-zA-Z0-9.]{1,20}$", *createNamePtr)
+
+		if !match {
+			createCommand.PrintDefaults()
+			msg := "hostname needs to be alphanumerical and less than 15 characters"
+			return nil, errors.New(msg)
+		}
+
+		ci.Hostname = *createNamePtr
+		if *createSizePtr == "" {
+			createCommand.PrintDefaults()
+			msg := "size needs to be provided"
+			return nil, errors.New(msg)
+		}
+
+		ci.Group = *createHostGroupPtr
+		if *createHostGroupPtr == 0 {
+			createCommand.PrintDefaults()
+			msg := "hostgroup_id needs to be provided"
+			return nil, errors.New(msg)
+		}
+
+		ci.Profile = *createHostProfilePtr
+		if *createHostProfilePtr == "" {
+			createCommand.PrintDefaults()
+			msg := "compute_profile_id needs to be provided"
+			return nil, errors.New(msg)
+		}
+
+		ci.OperatingSystem = *createOSPtr
+		ci.Architecture = *createArchPtr
+		ci.Medium = *createMediumPtr
+		ci.PartitionTable = *createPtablePtr
+	}
+	if deleteCommand.Parsed() {
+		if err := o.setHost(ci, *deleteNamePtr, *deleteIDPtr, *deleteMACPtr, *deleteIPPtr); err != nil {
+			deleteCommand.PrintDefaults()
+			return nil, err
+		}
+
+	}
+	if templateCommand.Parsed() {
+		host, err := o.setOptionalHost(ci, *templateHostPtr, *templateIDPtr, *templateMACPtr, *templateIPPtr)
+		if err != nil {
+			templateCommand.PrintDefaults()
+			return nil, err
+		}
+		if *templateNamePtr == "" && !host {
+			templateCommand.PrintDefaults()
+			msg := "template name or host needs to be provided"
+			return nil, errors.New(msg)
+		}
+		if *templateFilePtr != "" && *templateNamePtr == "" {
+			templateCommand.PrintDefaults()
+			msg := "template name needs to be provided to diff a file"
+			return nil, errors.New(msg)
+		}
+		o.template.name = *templateNamePtr
+		o.template.file = *templateFilePtr
+		o.template.kind = *templateKindPtr
+	}
+	if rebuildCommand.Parsed() {
+		if err := o.setHost(ci, *rebuildNamePtr, *rebuildIDPtr, *rebuildMACPtr, *rebuildIPPtr); err != nil {
+			rebuildCommand.PrintDefaults()
+			return nil, err
+		}
+		if *rebuildPowerCyclePtr && *rebuildCancelPtr {
+			rebuildCommand.PrintDefaults()
+			msg := "powercycle and cancel cannot be used together"
+			return nil, errors.New(msg)
+		}
+		o.rebuild.powerCycle = *rebuildPowerCyclePtr
+		o.rebuild.cancel = *rebuildCancelPtr
+	}
+	if factsCommand.Parsed() {
+		host, err := o.setOptionalHost(ci, *factsNamePtr, *factsIDPtr, *factsMACPtr, *factsIPPtr)
+		if err != nil {
+			factsCommand.PrintDefaults()
+			return nil, err
+		}
+		if !host && *factsSearchPtr == "" && *factsBookmarkPtr == "" && *factsFactPtr == "" {
+			factsCommand.PrintDefaults()
+			msg := "hostname, search or fact needs to be provided"
+			return nil, errors.New(msg)
+		}
+		if countSet(host, *factsSearchPtr != "", *factsBookmarkPtr != "", *factsFactPtr != "") > 1 {
+			factsCommand.PrintDefaults()
+			msg := "only one of hostname, search, bookmark or fact can be provided"
+			return nil, errors.New(msg)
+		}
+		if *factsFactPtr != "" && *factsValuePtr == "" {
+			factsCommand.PrintDefaults()
+			msg := "value needs to be provided to search by fact"
+			return nil, errors.New(msg)
+		}
+		o.search = *factsSearchPtr
+		o.bookmark = *factsBookmarkPtr
+		o.bookmarkController = "fact_values"
+		o.facts.fact = *factsFactPtr
+		o.facts.value = *factsValuePtr
+	}
+	if reportCommand.Parsed() {
+		if err := o.setHost(ci, *reportNamePtr, *reportIDPtr, *reportMACPtr, *reportIPPtr); err != nil {
+			reportCommand.PrintDefaults()
+			return nil, err
+		}
+		o.report.outOfSync = *reportOutOfSyncPtr
+	}
+	if runCommand.Parsed() {
+		if *runSearchPtr == "" && *runBookmarkPtr == "" {
+			runCommand.PrintDefaults()
+			msg := "search or bookmark needs to be provided"
+			return nil, errors.New(msg)
+		}
+		if *runSearchPtr != "" && *runBookmarkPtr != "" {
+			runCommand.PrintDefaults()
+			msg := "only one of search or bookmark can be provided"
+			return nil, errors.New(msg)
+		}
+		if *runTemplatePtr == "" {
+			runCommand.PrintDefaults()
+			msg := "job template needs to be provided"
+			return nil, errors.New(msg)
+		}
+		o.search = *runSearchPtr
+		o.bookmark = *runBookmarkPtr
+		o.bookmarkController = "hosts"
+		o.run.template = *runTemplatePtr
+		o.run.inputs = runInputs
+	}
+	if auditCommand.Parsed() {
+		host, err := o.setOptionalHost(ci, *auditNamePtr, *auditIDPtr, *auditMACPtr, *auditIPPtr)
+		if err != nil {
+			auditCommand.PrintDefaults()
+			return nil, err
+		}
+		if !host && *auditUserPtr == "" && *auditActionPtr == "" && *auditBookmarkPtr == "" {
+			auditCommand.PrintDefaults()
+			msg := "hostname, user, action or bookmark needs to be provided"
+			return nil, errors.New(msg)
+		}
+		o.audit.since = *auditSincePtr
+		o.audit.user = *auditUserPtr
+		o.audit.action = *auditActionPtr
+		o.bookmark = *auditBookmarkPtr
+		o.bookmarkController = "audits"
+	}
+	if userCommand.Parsed() {
+		if *userLoginPtr == "" {
+			userCommand.PrintDefaults()
+			msg := "login needs to be provided"
+			return nil, errors.New(msg)
+		}
+		o.user.login = *userLoginPtr
+		o.user.firstName = *userFirstNamePtr
+		o.user.lastName = *userLastNamePtr
+		o.user.mail = *userMailPtr
+		o.user.authSource = *userAuthSourcePtr
+		userCommand.Visit(func(f *flag.Flag) {
+			if f.Name == "admin" {
+				o.user.admin = userAdminPtr
+			}
+		})
+		o.user.roles = splitList(*userRolesPtr)
+		o.user.organizations = splitList(*userOrgsPtr)
+		o.user.locations = splitList(*userLocationsPtr)
+	}
+	if groupCommand.Parsed() {
+		if *groupNamePtr == "" {
+			groupCommand.PrintDefaults()
+			msg := "usergroup name needs to be provided"
+			return nil, errors.New(msg)
+		}
+		if *groupExternalPtr != "" && *groupAuthSourcePtr == 0 {
+			groupCommand.PrintDefaults()
+			msg := "authsource needs to be provided to map an external group"
+			return nil, errors.New(msg)
+		}
+		o.usergroup.name = *groupNamePtr
+		o.usergroup.users = splitList(*groupUsersPtr)
+		o.usergroup.roles = splitList(*groupRolesPtr)
+		o.usergroup.external = *groupExternalPtr
+		o.usergroup.authSource = *groupAuthSourcePtr
+		o.usergroup.refresh = *groupRefreshPtr
+	}
+	if permCommand.Parsed() {
+		if *permRolePtr != "" && (*permResourcePtr != "" || *permBookmarkPtr != "") {
+			permCommand.PrintDefaults()
+			msg := "resource and bookmark cannot be used with role"
+			return nil, errors.New(msg)
+		}
+		o.bookmark = *permBookmarkPtr
+		o.bookmarkController = "permissions"
+		o.permissions.role = *permRolePtr
+		o.permissions.resourceType = *permResourcePtr
+	}
+	if tokenCommand.Parsed() {
+		switch o.action {
+		case createArg:
+			if *tokenNamePtr == "" {
+				tokenCommand.PrintDefaults()
+				msg := "token name needs to be provided"
+				return nil, errors.New(msg)
+			}
+		case revokeArg:
+			if *tokenIDPtr == 0 {
+				tokenCommand.PrintDefaults()
+				msg := "token id needs to be provided"
+				return nil, errors.New(msg)
+			}
+		case listArg:
+		default:
+			msg := "token action should be one of create, list or revoke"
+			return nil, errors.New(msg)
+		}
+		if *tokenBookmarkPtr != "" && o.action != listArg {
+			tokenCommand.PrintDefaults()
+			msg := "bookmark can only be used to list tokens"
+			return nil, errors.New(msg)
+		}
+		o.bookmark = *tokenBookmarkPtr
+		o.bookmarkController = "personal_access_tokens"
+		o.token.user = *tokenUserPtr
+		if o.token.user == "" {
+			o.token.user = ci.Username
+		}
+		o.token.name = *tokenNamePtr
+		o.token.id = *tokenIDPtr
+		o.token.expires = *tokenExpiresPtr
+	}
+	if encCommand.Parsed() {
+		if err := o.setHost(ci, *encNamePtr, *encIDPtr, *encMACPtr, *encIPPtr); err != nil {
+			encCommand.PrintDefaults()
+			return nil, err
+		}
+	}
+	if overrideCommand.Parsed() {
+		if *overrideClassPtr == "" || *overrideParamPtr == "" {
+			overrideCommand.PrintDefaults()
+			msg := "puppet class and parameter need to be provided"
+			return nil, errors.New(msg)
+		}
+		if *overrideValuePtr != "" && *overrideMatchPtr == "" {
+			overrideCommand.PrintDefaults()
+			msg := "matcher needs to be provided to set a value"
+			return nil, errors.New(msg)
+		}
+		o.override.class = *overrideClassPtr
+		o.override.param = *overrideParamPtr
+		o.override.match = *overrideMatchPtr
+		o.override.value = *overrideValuePtr
+	}
+	if ansibleCommand.Parsed() {
+		host, err := o.setOptionalHost(ci, *ansibleNamePtr, *ansibleIDPtr, *ansibleMACPtr, *ansibleIPPtr)
+		if err != nil {
+			ansibleCommand.PrintDefaults()
+			return nil, err
+		}
+		switch o.action {
+		case rolesArg, assignArg, playArg:
+			if host == (*ansibleHostgroupPtr != "") {
+				ansibleCommand.PrintDefaults()
+				msg := "either hostname or hostgroup needs to be provided"
+				return nil, errors.New(msg)
+			}
+			if o.action == assignArg && *ansibleRolesPtr == "" {
+				ansibleCommand.PrintDefaults()
+				msg := "ansible roles need to be provided"
+				return nil, errors.New(msg)
+			}
+		case variableArg:
+			if *ansibleVariablePtr == "" || *ansibleMatchPtr == "" || *ansibleValuePtr == "" {
+				ansibleCommand.PrintDefaults()
+				msg := "variable, match and value need to be provided"
+				return nil, errors.New(msg)
+			}
+		default:
+			msg := "ansible action should be one of roles, assign, play or variable"
+			return nil, errors.New(msg)
+		}
+		o.ansible.hostgroup = *ansibleHostgroupPtr
+		o.ansible.roles = splitList(*ansibleRolesPtr)
+		o.ansible.variable = *ansibleVariablePtr
+		o.ansible.match = *ansibleMatchPtr
+		o.ansible.value = *ansibleValuePtr
+	}
+	if bulkCommand.Parsed() {
+		if *bulkSearchPtr == "" && *bulkBookmarkPtr == "" {
+			bulkCommand.PrintDefaults()
+			msg := "search or bookmark needs to be provided"
+			return nil, errors.New(msg)
+		}
+		if *bulkSearchPtr != "" && *bulkBookmarkPtr != "" {
+			bulkCommand.PrintDefaults()
+			msg := "only one of search or bookmark can be provided"
+			return nil, errors.New(msg)
+		}
+		var missing bool
+		switch o.action {
+		case "destroy":
+			missing = !*bulkConfirmPtr
+		case "hostgroup":
+			missing = *bulkHostgroupPtr == ""
+		case "owner":
+			missing = *bulkOwnerPtr == ""
+		case "environment":
+			missing = *bulkEnvironmentPtr == ""
+		case "power":
+			missing = *bulkPowerPtr == ""
+		case "build":
+		default:
+			msg := "bulk action should be one of destroy, hostgroup, owner, environment, build or power"
+			return nil, errors.New(msg)
+		}
+		if missing {
+			bulkCommand.PrintDefaults()
+			msg := "the flag of the " + o.action + " bulk action needs to be provided"
+			return nil, errors.New(msg)
+		}
+		o.search = *bulkSearchPtr
+		o.bookmark = *bulkBookmarkPtr
+		o.bookmarkController = "hosts"
+		o.bulk = bulkOptions{
+			hostgroup:   *bulkHostgroupPtr,
+			owner:       *bulkOwnerPtr,
+			environment: *bulkEnvironmentPtr,
+			power:       *bulkPowerPtr,
+			reboot:      *bulkRebootPtr,
+			confirm:     *bulkConfirmPtr,
+		}
+	}
+	if settingsCommand.Parsed() {
+		switch o.action {
+		case "get":
+			if *settingsNamePtr != "" && *settingsBookmarkPtr != "" {
+				settingsCommand.PrintDefaults()
+				msg := "only one of setting name or bookmark can be provided"
+				return nil, errors.New(msg)
+			}
+		case "set":
+			if *settingsNamePtr == "" || *settingsValuePtr == "" {
+				settingsCommand.PrintDefaults()
+				msg := "setting name and value need to be provided"
+				return nil, errors.New(msg)
+			}
+		case "check":
+			if *settingsFilePtr == "" {
+				settingsCommand.PrintDefaults()
+				msg := "file of expected settings needs to be provided"
+				return nil, errors.New(msg)
+			}
+		default:
+			msg := "settings action should be one of get, set or check"
+			return nil, errors.New(msg)
+		}
+		if *settingsBookmarkPtr != "" && o.action != "get" {
+			settingsCommand.PrintDefaults()
+			msg := "bookmark can only be used to get settings"
+			return nil, errors.New(msg)
+		}
+		o.bookmark = *settingsBookmarkPtr
+		o.bookmarkController = "settings"
+		o.settings.name = *settingsNamePtr
+		o.settings.value = *settingsValuePtr
+		o.settings.file = *settingsFilePtr
+	}
+	ci.Size = *createSizePtr
+
+	return o, nil
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+func TestHostSelectorFlags(t *testing.T) {
+
+	tt := []struct {
+		name             string
+		args             []string
+		expectedhostname string
+		expectedselector string
+		expectedresult   string
+	}{
+		{name: "name", args: []string{"/fake/loc/main", "delete", "-name=testdev"}, expectedhostname: "testdev"},
+		{name: "id", args: []string{"/fake/loc/main", "report", "-id=42"}, expectedselector: "42"},
+		{name: "mac", args: []string{"/fake/loc/main", "delete", "-mac=52:54:00:12:34:56"}, expectedselector: "52:54:00:12:34:56"},
+		{name: "ip", args: []string{"/fake/loc/main", "enc", "-ip=10.0.0.15"}, expectedselector: "10.0.0.15"},
+		{name: "name and ip", args: []string{"/fake/loc/main", "rebuild", "-name=testdev", "-ip=10.0.0.15"}, expectedresult: "only one of name, id, mac or ip can be provided"},
+		{name: "mac and ip", args: []string{"/fake/loc/main", "delete", "-mac=52:54:00:12:34:56", "-ip=10.0.0.15"}, expectedresult: "only one of name, id, mac or ip can be provided"},
+		{name: "invalid mac", args: []string{"/fake/loc/main", "delete", "-mac=52:54:00:12:34"}, expectedresult: "mac [52:54:00:12:34] is not a valid mac address"},
+		{name: "dotted mac", args: []string{"/fake/loc/main", "rebuild", "-mac=5254.0012.3456"}, expectedresult: "mac [5254.0012.3456] is not a valid mac address"},
+		{name: "invalid ip", args: []string{"/fake/loc/main", "report", "-ip=10.0.0.256"}, expectedresult: "ip [10.0.0.256] is not a valid ip address"},
+		{name: "facts ip", args: []string{"/fake/loc/main", "facts", "-ip=10.0.0.15"}, expectedselector: "10.0.0.15"},
+		{name: "audit name", args: []string{"/fake/loc/main", "audit", "-name=deleted99"}, expectedhostname: "deleted99"},
+		{name: "audit id", args: []string{"/fake/loc/main", "audit", "-id=42"}, expectedselector: "42"},
+		{name: "template host", args: []string{"/fake/loc/main", "template", "-host=testdev"}, expectedhostname: "testdev"},
+		{name: "template mac", args: []string{"/fake/loc/main", "template", "-mac=52-54-00-12-34-56"}, expectedselector: "52-54-00-12-34-56"},
+		{name: "ansible mac", args: []string{"/fake/loc/main", "ansible", "play", "-mac=52:54:00:12:34:56"}, expectedselector: "52:54:00:12:34:56"},
+		{name: "ansible ip and hostgroup", args: []string{"/fake/loc/main", "ansible", "roles", "-ip=10.0.0.15", "-hostgroup=web"}, expectedresult: "either hostname or hostgroup needs to be provided"},
+		{name: "facts invalid ip", args: []string{"/fake/loc/main", "facts", "-ip=dev99"}, expectedresult: "ip [dev99] is not a valid ip address"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Args = tc.args
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: "http://test.com"}
+			opts, err := parseFlags(&api)
+			if tc.expectedresult != "" {
+				if err == nil || tc.expectedresult != err.Error() {
+					t.Errorf("Test %v result should be %v, got  `%v`", tc.name, tc.expectedresult, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Test %v unexpected error `%v`", tc.name, err)
+			}
+			if api.Hostname != tc.expectedhostname || opts.host != tc.expectedselector {
+				t.Errorf("Test %v should select host %q %q, got %q %q", tc.name, tc.expectedhostname, tc.expectedselector, api.Hostname, opts.host)
+			}
+		})
+
+	}
+}
+
+func TestRebuildFlags(t *testing.T) {
+
+	tt := []struct {
+		name           string
+		args           []string
+		expectedresult string
+	}{
+		{name: "name not set", args: []string{"/fake/loc/main", "rebuild", "-powercycle"}, expectedresult: "hostname needs to be provided"},
+		{name: "powercycle and cancel", args: []string{"/fake/loc/main", "rebuild", "-name=testdev", "-powercycle", "-cancel"}, expectedresult: "powercycle and cancel cannot be used together"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Args = tc.args
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: "http://test.com"}
+			_, err := parseFlags(&api)
+			if err == nil || tc.expectedresult != err.Error() {
+				t.Errorf("Test %v result should be %v, got  `%v`", tc.name, tc.expectedresult, err)
+			}
+			log.Printf("Response: %s", err)
+		})
+
+	}
+}
+
+func TestRunFlags(t *testing.T) {
+
+	os.Args = []string{"/fake/loc/main", "run", "-search=name ~ dev", "-template=Run Command", "-input", "command=uptime -p", "-input=timeout=5"}
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: "http://test.com"}
+	opts, err := parseFlags(&api)
+	if err != nil {
+		t.Fatalf("Could not parse flags correctly %v", err)
+	}
+	if opts.search != "name ~ dev" || opts.run.template != "Run Command" || opts.run.inputs["command"] != "uptime -p" || opts.run.inputs["timeout"] != "5" {
+		t.Errorf("unexpected flags parsed `%v %v %v`", opts.search, opts.run.template, opts.run.inputs)
+	}
+}
+
+func TestUserFlags(t *testing.T) {
+
+	os.Args = []string{"/fake/loc/main", "user", "-login=jdoe", "-roles=Viewer, Manager,", "-orgs=ACME"}
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: "http://test.com"}
+	opts, err := parseFlags(&api)
+	if err != nil {
+		t.Fatalf("Could not parse flags correctly %v", err)
+	}
+	if opts.user.login != "jdoe" || len(opts.user.roles) != 2 || opts.user.roles[1] != "Manager" || len(opts.user.organizations) != 1 || opts.user.locations != nil {
+		t.Errorf("unexpected flags parsed `%v %v %v %v`", opts.user.login, opts.user.roles, opts.user.organizations, opts.user.locations)
+	}
+}
+
+func TestTokenFlags(t *testing.T) {
+
+	tt := []struct {
+		name           string
+		args           []string
+		expectedresult string
+	}{
+		{name: "no action", args: []string{"/fake/loc/main", "token"}, expectedresult: "create, list or revoke token action is required"},
+		{name: "unknown action", args: []string{"/fake/loc/main", "token", "rotate"}, expectedresult: "token action should be one of create, list or revoke"},
+		{name: "create without name", args: []string{"/fake/loc/main", "token", "create", "-expires=24h"}, expectedresult: "token name needs to be provided"},
+		{name: "revoke without id", args: []string{"/fake/loc/main", "token", "revoke", "-user=jenkins"}, expectedresult: "token id needs to be provided"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Args = tc.args
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: "http://test.com"}
+			_, err := parseFlags(&api)
+			if err == nil || tc.expectedresult != err.Error() {
+				t.Errorf("Test %v result should be %v, got  `%v`", tc.name, tc.expectedresult, err)
+			}
+		})
+	}
+}
+
+func TestBookmarkFlags(t *testing.T) {
+
+	os.Args = []string{"/fake/loc/main", "bulk", "build", "-bookmark=dev hosts"}
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: "http://test.com"}
+	opts, err := parseFlags(&api)
+	if err != nil {
+		t.Fatalf("Could not parse flags correctly %v", err)
+	}
+	if opts.bookmark != "dev hosts" || opts.bookmarkController != "hosts" || opts.search != "" {
+		t.Errorf("unexpected flags parsed `%v %v %v`", opts.bookmark, opts.bookmarkController, opts.search)
+	}
+}
+
+func TestBulkFlags(t *testing.T) {
+
+	os.Args = []string{"/fake/loc/main", "bulk", "owner", "-search=name ~ dev", "-owner=jdoe", "-reboot"}
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: "http://test.com"}
+	opts, err := parseFlags(&api)
+	if err != nil {
+		t.Fatalf("Could not parse flags correctly %v", err)
+	}
+	if opts.bulk.owner != "jdoe" || !opts.bulk.reboot || opts.audit.user != "" || opts.rebuild.powerCycle {
+		t.Errorf("unexpected flags parsed `%+v %v %v`", opts.bulk, opts.audit.user, opts.rebuild.powerCycle)
+	}
+}
+
+func TestListBookmarkFlags(t *testing.T) {
+
+	tt := []struct {
+		name               string
+		args               []string
+		expectedcontroller string
+	}{
+		{name: "settings get", args: []string{"/fake/loc/main", "settings", "get", "-bookmark=puppet settings"}, expectedcontroller: "settings"},
+		{name: "permissions", args: []string{"/fake/loc/main", "permissions", "-resource=Host", "-bookmark=edit permissions"}, expectedcontroller: "permissions"},
+		{name: "token list", args: []string{"/fake/loc/main", "token", "list", "-bookmark=active tokens"}, expectedcontroller: "personal_access_tokens"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Args = tc.args
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: "http://test.com"}
+			opts, err := parseFlags(&api)
+			if err != nil {
+				t.Fatalf("Test %v unexpected error `%v`", tc.name, err)
+			}
+			if opts.bookmark == "" || tc.expectedcontroller != opts.bookmarkController {
+				t.Errorf("Test %v result should be %v, got  `%v %v`", tc.name, tc.expectedcontroller, opts.bookmark, opts.bookmarkController)
+			}
+		})
+	}
+}
+
+func TestBookmarkConflictFlags(t *testing.T) {
+
+	tt := []struct {
+		name           string
+		args           []string
+		expectedresult string
+	}{
+		{name: "facts name", args: []string{"/fake/loc/main", "facts", "-name=testdev", "-bookmark=dev hosts"}, expectedresult: "only one of hostname, search, bookmark or fact can be provided"},
+		{name: "facts search", args: []string{"/fake/loc/main", "facts", "-search=os", "-bookmark=dev hosts"}, expectedresult: "only one of hostname, search, bookmark or fact can be provided"},
+		{name: "facts fact", args: []string{"/fake/loc/main", "facts", "-ip=10.0.0.15", "-fact=os", "-value=centos"}, expectedresult: "only one of hostname, search, bookmark or fact can be provided"},
+		{name: "run search", args: []string{"/fake/loc/main", "run", "-search=name ~ dev", "-bookmark=dev hosts", "-template=Run Command"}, expectedresult: "only one of search or bookmark can be provided"},
+		{name: "bulk search", args: []string{"/fake/loc/main", "bulk", "build", "-search=name ~ dev", "-bookmark=dev hosts"}, expectedresult: "only one of search or bookmark can be provided"},
+		{name: "settings name", args: []string{"/fake/loc/main", "settings", "get", "-name=token_duration", "-bookmark=puppet settings"}, expectedresult: "only one of setting name or bookmark can be provided"},
+		{name: "settings set", args: []string{"/fake/loc/main", "settings", "set", "-name=token_duration", "-value=360", "-bookmark=puppet settings"}, expectedresult: "bookmark can only be used to get settings"},
+		{name: "permissions role", args: []string{"/fake/loc/main", "permissions", "-role=Viewer", "-bookmark=edit permissions"}, expectedresult: "resource and bookmark cannot be used with role"},
+		{name: "token revoke", args: []string{"/fake/loc/main", "token", "revoke", "-id=12", "-bookmark=active tokens"}, expectedresult: "bookmark can only be used to list tokens"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Args = tc.args
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: "http://test.com"}
+			_, err := parseFlags(&api)
+			if err == nil || tc.expectedresult != err.Error() {
+				t.Errorf("Test %v result should be %v, got  `%v`", tc.name, tc.expectedresult, err)
+			}
+		})
+	}
+}
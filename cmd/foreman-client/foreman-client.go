@@ -42,14 +42,29 @@ func main() {
 	ctx, cancel := context.WithTimeout(ctx, timeout*time.Second)
 	defer cancel()
 
+	level := foreman.LevelInfo
+	if name := os.Getenv("FOREMAN_LOG_LEVEL"); name != "" {
+		var err error
+		level, err = foreman.ParseLevel(name)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+	}
+
 	connection := foreman.ConnectionInfo{
 		Username: user,
 		Password: password,
 		BaseURL:  url,
 		Client:   client,
+		Logger:   foreman.NewStdLogger(log.New(os.Stderr, "", log.LstdFlags), level),
+		Middleware: []foreman.Middleware{
+			foreman.UserAgent("foreman-client/" + version),
+			foreman.RequestID(),
+			foreman.Retry(3, time.Second),
+		},
 	}
 
-	_, err := connection.CheckUserInput()
+	opts, err := parseFlags(&connection)
 	if err != nil {
 		log.Fatalf("Error: %s", err.Error())
 	}
@@ -65,6 +80,62 @@ func main() {
 		log.Fatalf("Error: Status code 422 indicates this hostname already exists in a terminated state. Try again with a different hostname")
 	}
 
+	if opts.bookmark != "" {
+		opts.search, err = connection.ResolveBookmark(ctx, opts.bookmark, opts.bookmarkController)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		log.Printf("Response: Using bookmark [%s] search %s", opts.bookmark, opts.search)
+	}
+
+	if opts.host != "" {
+		host, err := connection.GetHostBy(ctx, opts.host)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		connection.Hostname = host.Name
+		log.Printf("Response: Using host [%s] for %s", connection.Hostname, opts.host)
+	}
+
+	switch opts.command {
+	case templateArg:
+		runTemplate(ctx, &connection, opts)
+	case rebuildArg:
+		runRebuild(ctx, &connection, opts)
+	case factsArg:
+		runFacts(ctx, &connection, opts)
+	case reportArg:
+		runReport(ctx, &connection, opts)
+	case runArg:
+		runJob(ctx, &connection, opts)
+	case auditArg:
+		runAudit(ctx, &connection, opts)
+	case userArg:
+		runUser(ctx, &connection, opts)
+	case groupArg:
+		runUsergroup(ctx, &connection, opts)
+	case permArg:
+		runPermissions(ctx, &connection, opts)
+	case tokenArg:
+		runToken(ctx, &connection, opts)
+	case encArg:
+		runENC(ctx, &connection)
+	case overrideArg:
+		runOverride(ctx, &connection, opts)
+	case ansibleArg:
+		runAnsible(ctx, &connection, opts)
+	case bulkArg:
+		runBulk(ctx, &connection, opts)
+	case settingsArg:
+		runSettings(ctx, &connection, opts)
+	default:
+		runHost(ctx, &connection)
+	}
+}
+
+// runHost creates or deletes the host provided
+func runHost(ctx context.Context, connection *foreman.ConnectionInfo) {
+
 	exists, _, err := connection.CheckHost(ctx)
 	if err != nil {
 		log.Fatalf("Error: %s", err.Error())
@@ -72,7 +143,7 @@ func main() {
 	if exists {
 		log.Printf("Response: %s already exists ⚠️", connection.Hostname)
 
-		if connection.Action == "delete" {
+		if connection.Action == deleteArg {
 			_, deleted, err := connection.DeleteHost(ctx)
 			if err != nil {
 				log.Fatalf("Error: %s", err.Error())
@@ -82,7 +153,7 @@ func main() {
 			log.Fatalf("Cannot create a host that already exists. Please try a different host name.")
 		}
 	} else {
-		if connection.Action == "delete" {
+		if connection.Action == deleteArg {
 			log.Printf("Response: [%s] doesn't exist so let's not do any delete action", connection.Hostname)
 		} else {
 			log.Printf("Response: [%s] doesn't exist so let's create the host via foreman", connection.Hostname)
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+// overrideOptions contains the flags of the override command
+type overrideOptions struct {
+	class string
+	param string
+	match string
+	value string
+}
+
+// runENC prints the effective ENC of a host as json
+func runENC(ctx context.Context, connection *foreman.ConnectionInfo) {
+
+	enc, err := connection.GetHostENC(ctx)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+
+	printJSON(enc)
+}
+
+// runOverride sets the override value of a smart class parameter for a matcher, or lists the current overrides
+func runOverride(ctx context.Context, connection *foreman.ConnectionInfo, opts *options) {
+
+	id, err := connection.ResolveSmartClassParameter(ctx, opts.override.class, opts.override.param)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+
+	if opts.override.value == "" {
+		values, err := connection.ListOverrideValues(ctx, id)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		for _, v := range values {
+			if opts.override.match == "" || opts.override.match == v.Match {
+				fmt.Printf("%-40s %v\n", v.Match, v.Value)
+			}
+		}
+		return
+	}
+
+	param, err := connection.GetSmartClassParameter(ctx, id)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	if param.Override == nil || !*param.Override {
+		override := true
+		_, err = connection.UpdateSmartClassParameter(ctx, id, foreman.SmartClassParameter{Override: &override})
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+	}
+
+	value, err := connection.SetOverrideValue(ctx, id, opts.override.match, opts.override.value)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	log.Printf("Response: %s::%s is %v for %s", opts.override.class, opts.override.param, value.Value, value.Match)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+// rebuildOptions contains the flags of the rebuild command
+type rebuildOptions struct {
+	powerCycle bool
+	cancel     bool
+}
+
+// runRebuild rebuilds an existing host in place or cancels its pending build
+func runRebuild(ctx context.Context, connection *foreman.ConnectionInfo, opts *options) {
+
+	exists, _, err := connection.CheckHost(ctx)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	if !exists {
+		log.Fatalf("Response: [%s] doesn't exist so there is nothing to rebuild", connection.Hostname)
+	}
+
+	if opts.rebuild.cancel {
+		err = connection.CancelBuild(ctx)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		log.Printf("Response: The build of [%s] was cancelled", connection.Hostname)
+		return
+	}
+
+	err = connection.RebuildHost(ctx, opts.rebuild.powerCycle)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	log.Printf("Response: The host [%s] was set to rebuild", connection.Hostname)
+
+	status, err := connection.GetBuildStatus(ctx)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	log.Printf("Response: Build status of [%s] is %s", connection.Hostname, status.StatusLabel)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+// reportOptions contains the flags of the report command
+type reportOptions struct {
+	outOfSync time.Duration
+}
+
+// runReport shows the last config report of a host and fails if it has failures or is out of sync
+func runReport(ctx context.Context, connection *foreman.ConnectionInfo, opts *options) {
+
+	report, err := connection.GetLastConfigReport(ctx)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+
+	fmt.Printf("Report %d for %s at %s (%s)\n", report.ID, report.HostName, report.ReportedAt, report.Origin)
+	fmt.Printf("applied=%d restarted=%d failed=%d failed_restarts=%d skipped=%d pending=%d\n",
+		report.Status.Applied, report.Status.Restarted, report.Status.Failed,
+		report.Status.FailedRestarts, report.Status.Skipped, report.Status.Pending)
+	for _, l := range report.Logs {
+		fmt.Printf("%-7s %s: %s\n", l.Level, l.Source, l.Message)
+	}
+
+	if report.Failures() > 0 {
+		log.Fatalf("Error: The last report of [%s] has %d failures", connection.Hostname, report.Failures())
+	}
+
+	outOfSync, err := report.OutOfSync(opts.report.outOfSync)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	if outOfSync {
+		log.Fatalf("Error: The last report of [%s] is older than %s, the host is out of sync", connection.Hostname, opts.report.outOfSync)
+	}
+
+	log.Printf("Response: [%s] converged successfully", connection.Hostname)
+}
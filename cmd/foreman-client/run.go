@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+// poll interval for job invocations
+const (
+	jobInterval = 5
+)
+
+// runOptions contains the flags of the run command
+type runOptions struct {
+	template string
+	inputs   map[string]string
+}
+
+// runJob runs a job template against the hosts matching a search and prints the output of each host
+func runJob(ctx context.Context, connection *foreman.ConnectionInfo, opts *options) {
+
+	templateID, err := connection.ResolveJobTemplate(ctx, opts.run.template)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+
+	job, err := connection.CreateJobInvocation(ctx, templateID, opts.search, opts.run.inputs)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	log.Printf("Response: Job invocation %d [%s] started", job.ID, job.Description)
+
+	job, err = connection.WaitJobInvocation(ctx, job.ID, jobInterval*time.Second)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+
+	hosts, err := connection.ListJobInvocationHosts(ctx, job.ID)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	for _, host := range hosts {
+		output, err := connection.GetJobOutput(ctx, job.ID, host.ID)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		fmt.Printf("==> %s (%s)\n%s\n", host.Name, host.JobStatus, output)
+	}
+
+	if job.Failed > 0 {
+		log.Fatalf("Error: Job invocation %d failed on %d of %d hosts", job.ID, job.Failed, job.Total)
+	}
+	log.Printf("Response: Job invocation %d succeeded on %d hosts", job.ID, job.Succeeded)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+// settingsOptions contains the flags of the settings command
+type settingsOptions struct {
+	name  string
+	value string
+	file  string
+}
+
+// runSettings reads, updates or checks foreman settings for drift
+func runSettings(ctx context.Context, connection *foreman.ConnectionInfo, opts *options) {
+
+	switch opts.action {
+	case "get":
+		if opts.settings.name == "" {
+			settings, err := connection.ListSettings(ctx, opts.search)
+			if err != nil {
+				log.Fatalf("Error: %s", err.Error())
+			}
+			for _, s := range settings {
+				fmt.Printf("%-45s %v\n", s.Name, s.Value)
+			}
+			return
+		}
+		setting, err := connection.GetSetting(ctx, opts.settings.name)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		fmt.Printf("%v\n", setting.Value)
+	case "set":
+		setting, err := connection.UpdateSetting(ctx, opts.settings.name, opts.settings.value)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		log.Printf("Response: %s is now %v", setting.Name, setting.Value)
+	case "check":
+		data, err := ioutil.ReadFile(opts.settings.file)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		var expected map[string]interface{}
+		err = json.Unmarshal(data, &expected)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		drift, err := connection.CheckSettings(ctx, expected)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		for _, d := range drift {
+			fmt.Printf("%-45s expected %v, got %v\n", d.Name, d.Expected, d.Actual)
+		}
+		if len(drift) > 0 {
+			log.Printf("Error: %d of %d settings have drifted", len(drift), len(expected))
+			os.Exit(1)
+		}
+		log.Printf("Response: all %d settings match", len(expected))
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+// templateOptions contains the flags of the template command
+type templateOptions struct {
+	name string
+	file string
+	kind string
+}
+
+// runTemplate shows, diffs or renders a provisioning template
+func runTemplate(ctx context.Context, connection *foreman.ConnectionInfo, opts *options) {
+
+	if opts.template.name == "" {
+		rendered, err := connection.RenderHostTemplate(ctx, connection.Hostname, opts.template.kind)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		fmt.Print(rendered)
+		return
+	}
+
+	id, err := connection.ResolveProvisioningTemplate(ctx, opts.template.name)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+
+	if connection.Hostname != "" {
+		hostID, err := connection.ResolveHost(ctx, connection.Hostname)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		rendered, err := connection.RenderProvisioningTemplate(ctx, id, hostID)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		fmt.Print(rendered)
+		return
+	}
+
+	template, err := connection.GetProvisioningTemplate(ctx, id)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+
+	if opts.template.file == "" {
+		fmt.Print(template.Template)
+		return
+	}
+
+	local, err := ioutil.ReadFile(opts.template.file)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+
+	diff := diffLines(template.Template, string(local))
+	if len(diff) == 0 {
+		log.Printf("Response: [%s] matches %s", template.Name, opts.template.file)
+		return
+	}
+
+	fmt.Printf("--- %s (foreman)\n+++ %s\n", template.Name, opts.template.file)
+	for _, line := range diff {
+		fmt.Println(line)
+	}
+	os.Exit(1)
+}
+
+// diffLines returns the lines removed from a (prefixed -) and added in b (prefixed +), nil if they are equal
+func diffLines(a string, b string) []string {
+
+	if a == b {
+		return nil
+	}
+
+	x := strings.Split(strings.TrimSuffix(a, "\n"), "\n")
+	y := strings.Split(strings.TrimSuffix(b, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var diff []string
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			i++
+			j++
+		case j < len(y) && (i == len(x) || lcs[i][j+1] >= lcs[i+1][j]):
+			diff = append(diff, "+"+y[j])
+			j++
+		default:
+			diff = append(diff, "-"+x[i])
+			i++
+		}
+	}
+
+	return diff
+}
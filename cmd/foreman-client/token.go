@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+// tokenOptions contains the flags of the token command
+type tokenOptions struct {
+	user    string
+	name    string
+	id      int
+	expires time.Duration
+}
+
+// runToken creates, lists or revokes personal access tokens of a user
+func runToken(ctx context.Context, connection *foreman.ConnectionInfo, opts *options) {
+
+	userID, err := connection.ResolveUser(ctx, opts.token.user)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+
+	switch opts.action {
+	case "create":
+		var expiresAt time.Time
+		if opts.token.expires > 0 {
+			expiresAt = time.Now().Add(opts.token.expires)
+		}
+		token, err := connection.CreatePersonalAccessToken(ctx, userID, opts.token.name, expiresAt)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		log.Printf("Response: Token [%s] created with id %d, it will not be shown again", token.Name, token.ID)
+		fmt.Println(token.TokenValue)
+	case "list":
+		tokens, err := connection.PersonalAccessTokensResource(userID).List(ctx, opts.search)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		for _, t := range tokens {
+			fmt.Printf("%-6d %-30s active=%-5t expires=%s last_used=%s\n", t.ID, t.Name, t.Active, t.ExpiresAt, t.LastUsedAt)
+		}
+	case "revoke":
+		err := connection.RevokePersonalAccessToken(ctx, userID, opts.token.id)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		log.Printf("Response: Token %d of [%s] was revoked", opts.token.id, opts.token.user)
+	}
+}
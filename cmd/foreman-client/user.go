@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+// userOptions contains the flags of the user command
+type userOptions struct {
+	login         string
+	firstName     string
+	lastName      string
+	mail          string
+	authSource    int
+	admin         *bool
+	roles         []string
+	organizations []string
+	locations     []string
+}
+
+// usergroupOptions contains the flags of the usergroup command
+type usergroupOptions struct {
+	name       string
+	users      []string
+	roles      []string
+	external   string
+	authSource int
+	refresh    bool
+}
+
+// permissionsOptions contains the flags of the permissions command
+type permissionsOptions struct {
+	role         string
+	resourceType string
+}
+
+// runUser creates the user if it doesn't exist, otherwise updates it, and assigns its roles, organizations and locations,
+// the ones of an existing user are added to those it already has
+func runUser(ctx context.Context, connection *foreman.ConnectionInfo, opts *options) {
+
+	user := foreman.User{
+		Login:        opts.user.login,
+		Firstname:    opts.user.firstName,
+		Lastname:     opts.user.lastName,
+		Mail:         opts.user.mail,
+		Admin:        opts.user.admin,
+		AuthSourceID: opts.user.authSource,
+		Password:     os.Getenv("FOREMAN_NEW_USER_PASSWORD"),
+	}
+	var err error
+	user.RoleIDs, err = resolveAll(ctx, opts.user.roles, connection.ResolveRole)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	user.OrganizationIDs, err = resolveAll(ctx, opts.user.organizations, connection.ResolveOrganization)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	user.LocationIDs, err = resolveAll(ctx, opts.user.locations, connection.ResolveLocation)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+
+	id, err := connection.ResolveUser(ctx, opts.user.login)
+	if err != nil && !errors.Is(err, foreman.ErrNotFound) {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	if err != nil {
+		log.Printf("Response: [%s] doesn't exist so let's create the user via foreman", opts.user.login)
+		created, err := connection.CreateUser(ctx, user)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		log.Printf("Response: The user [%s] was created with id %d", created.Login, created.ID)
+		return
+	}
+
+	roleIDs, organizationIDs, locationIDs := user.RoleIDs, user.OrganizationIDs, user.LocationIDs
+	user.Password = ""
+	user.RoleIDs, user.OrganizationIDs, user.LocationIDs = nil, nil, nil
+	updated, err := connection.UpdateUser(ctx, id, user)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	if len(roleIDs) > 0 || len(organizationIDs) > 0 || len(locationIDs) > 0 {
+		_, err = connection.AddUserAssignments(ctx, id, roleIDs, organizationIDs, locationIDs)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+	}
+	log.Printf("Response: The user [%s] was updated", updated.Login)
+}
+
+// runUsergroup creates or updates a usergroup and maps an external group to it
+func runUsergroup(ctx context.Context, connection *foreman.ConnectionInfo, opts *options) {
+
+	userIDs, err := resolveAll(ctx, opts.usergroup.users, connection.ResolveUser)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	roleIDs, err := resolveAll(ctx, opts.usergroup.roles, connection.ResolveRole)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	group := foreman.Usergroup{Name: opts.usergroup.name, UserIDs: userIDs, RoleIDs: roleIDs}
+
+	id, err := connection.ResolveUsergroup(ctx, opts.usergroup.name)
+	if err != nil && !errors.Is(err, foreman.ErrNotFound) {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	if err != nil {
+		created, err := connection.CreateUsergroup(ctx, group)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		id = created.ID
+		log.Printf("Response: The usergroup [%s] was created with id %d", created.Name, created.ID)
+	} else if len(group.UserIDs) > 0 || len(group.RoleIDs) > 0 {
+		_, err = connection.AddUsergroupMembers(ctx, id, group.UserIDs, group.RoleIDs)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		log.Printf("Response: The usergroup [%s] was updated", opts.usergroup.name)
+	}
+
+	if opts.usergroup.external != "" {
+		ext := foreman.ExternalUsergroup{Name: opts.usergroup.external, AuthSourceID: opts.usergroup.authSource}
+		_, err = connection.CreateExternalUsergroup(ctx, id, ext)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		log.Printf("Response: [%s] was mapped to the usergroup [%s]", opts.usergroup.external, opts.usergroup.name)
+	}
+
+	if opts.usergroup.refresh {
+		externals, err := connection.ListExternalUsergroups(ctx, id)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		for _, ext := range externals {
+			err = connection.RefreshExternalUsergroup(ctx, id, ext.ID)
+			if err != nil {
+				log.Fatalf("Error: %s", err.Error())
+			}
+			log.Printf("Response: [%s] was refreshed", ext.Name)
+		}
+	}
+}
+
+// runPermissions prints the filters of a role or the permissions of a resource type
+func runPermissions(ctx context.Context, connection *foreman.ConnectionInfo, opts *options) {
+
+	if opts.permissions.role == "" {
+		search := opts.search
+		if opts.permissions.resourceType != "" {
+			search = fmt.Sprintf("resource_type = %q", opts.permissions.resourceType)
+			if opts.search != "" {
+				search += " and (" + opts.search + ")"
+			}
+		}
+		permissions, err := connection.ListPermissions(ctx, search)
+		if err != nil {
+			log.Fatalf("Error: %s", err.Error())
+		}
+		for _, p := range permissions {
+			fmt.Printf("%-20s %s\n", p.ResourceType, p.Name)
+		}
+		return
+	}
+
+	id, err := connection.ResolveRole(ctx, opts.permissions.role)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	filters, err := connection.ListRoleFilters(ctx, id)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+	for _, f := range filters {
+		fmt.Printf("%s (unlimited=%t) %s\n", f.ResourceType, f.Unlimited != nil && *f.Unlimited, f.Search)
+		for _, p := range f.Permissions {
+			fmt.Printf("    %s\n", p.Name)
+		}
+	}
+}
+
+// resolveAll resolves every name to an id, returning the error of the first one that cannot be resolved
+func resolveAll(ctx context.Context, names []string, resolve func(context.Context, string) (int, error)) ([]int, error) {
+
+	var ids []int
+	for _, name := range names {
+		id, err := resolve(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// apidoc is the apipie description of the foreman api served at /apidoc/v2.json
+type apidoc struct {
+	Docs struct {
+		APIURL    string                 `json:"api_url"`
+		Resources map[string]apiResource `json:"resources"`
+	} `json:"docs"`
+}
+
+// apiResource is a controller of the foreman api, e.g. http_proxies
+type apiResource struct {
+	ID      string      `json:"id"`
+	Name    string      `json:"name"`
+	Methods []apiMethod `json:"methods"`
+}
+
+// apiMethod is an action of a controller, e.g. index or create
+type apiMethod struct {
+	Name   string     `json:"name"`
+	Apis   []apiRoute `json:"apis"`
+	Params []apiParam `json:"params"`
+}
+
+// apiRoute is a route an action is served on
+type apiRoute struct {
+	APIURL           string `json:"api_url"`
+	HTTPMethod       string `json:"http_method"`
+	ShortDescription string `json:"short_description"`
+}
+
+// apiParam is a parameter of an action, hashes contain the parameters of the resource they wrap
+type apiParam struct {
+	Name         string     `json:"name"`
+	ExpectedType string     `json:"expected_type"`
+	Validator    string     `json:"validator"`
+	Required     bool       `json:"required"`
+	Params       []apiParam `json:"params"`
+}
+
+// readAPIDoc reads the apipie json at the path provided
+func readAPIDoc(path string) (*apidoc, error) {
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc apidoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s is not apipie json: %w", path, err)
+	}
+	if len(doc.Docs.Resources) == 0 {
+		return nil, fmt.Errorf("%s does not describe any resources", path)
+	}
+
+	return &doc, nil
+}
+
+// method returns the action with the name provided
+func (r apiResource) method(name string) (apiMethod, bool) {
+	for _, m := range r.Methods {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return apiMethod{}, false
+}
+
+// serves reports whether the action is served on the http method and url provided
+func (m apiMethod) serves(httpMethod string, url string) bool {
+	for _, api := range m.Apis {
+		if api.HTTPMethod == httpMethod && api.APIURL == url {
+			return true
+		}
+	}
+	return false
+}
+
+// collection returns the route the action lists the collection with the id provided on, e.g. /api/users/:user_id/ssh_keys,
+// preferring a top level route over the nested ones
+func (m apiMethod) collection(id string) (string, bool) {
+
+	var found string
+	for _, api := range m.Apis {
+		if api.HTTPMethod != "GET" || api.APIURL[strings.LastIndex(api.APIURL, "/")+1:] != id {
+			continue
+		}
+		if !strings.Contains(api.APIURL, ":") {
+			return api.APIURL, true
+		}
+		if found == "" {
+			found = api.APIURL
+		}
+	}
+
+	return found, found != ""
+}
@@ -0,0 +1,525 @@
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// initialisms are the words written in upper case in go identifiers
+var initialisms = map[string]bool{
+	"API": true, "BMC": true, "CA": true, "CIDR": true, "DHCP": true, "DN": true, "DNS": true, "HTTP": true,
+	"HTTPS": true, "ID": true, "IP": true, "JSON": true, "LDAP": true, "MAC": true, "SSH": true, "SSL": true,
+	"TFTP": true, "TLS": true, "UI": true, "URL": true, "UUID": true,
+}
+
+// resourceDef is a resource the generator emits a type and an accessor for
+type resourceDef struct {
+	Collection string
+	Wrapper    string
+	Type       string
+	Accessor   string
+	Args       []string
+	Elem       []string
+	Fields     []fieldDef
+	Actions    []actionDef
+}
+
+// fieldDef is a field of a generated type
+type fieldDef struct {
+	Name string
+	Type string
+	JSON string
+}
+
+// actionDef is an action of a generated resource that is not list, get, create, update or delete, e.g. webhooks test
+type actionDef struct {
+	Name       string
+	HTTPMethod string
+	Route      string
+	Summary    string
+	Args       []string
+	Elem       []string
+	Params     []fieldDef
+}
+
+// constDef is a path constant the generator declares, e.g. webhooksapi
+type constDef struct {
+	Name string
+	Path string
+}
+
+// Label returns the collection as it reads in a sentence, e.g. http proxies
+func (r resourceDef) Label() string {
+	return strings.ReplaceAll(r.Collection, "_", " ")
+}
+
+// ParamsType returns the name of the type holding the parameters of the action
+func (a actionDef) ParamsType() string {
+	return a.Name + "Params"
+}
+
+// declarations are the names and the path constants package foreman declares by hand
+type declarations struct {
+	names map[string]bool
+	paths map[string]string
+}
+
+var fileTemplate = template.Must(template.New("file").Parse(`// Code generated by foreman-gen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+{{if .Actions}}
+import "context"
+{{end}}
+const (
+{{- range .Consts}}
+	{{.Name}} = "{{.Path}}"
+{{- end}}
+)
+{{range .Resources}}
+// {{.Type}} is generated from the {{.Collection}} resource of the foreman api
+type {{.Type}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`" + `json:"{{.JSON}},omitempty"` + "`" + `
+{{- end}}
+}
+
+// {{.Accessor}} returns the typed resource for {{.Label}}
+func (ci *ConnectionInfo) {{.Accessor}}({{if .Args}}{{range $i, $a := .Args}}{{if $i}}, {{end}}{{$a}}{{end}} string{{end}}) *Resource[{{.Type}}] {
+	return NewResource[{{.Type}}](ci, "{{.Wrapper}}"{{range .Elem}}, {{.}}{{end}})
+}
+{{range .Actions}}{{if .Params}}
+// {{.ParamsType}} are the parameters of {{.Name}}
+type {{.ParamsType}} struct {
+{{- range .Params}}
+	{{.Name}} {{.Type}} ` + "`" + `json:"{{.JSON}},omitempty"` + "`" + `
+{{- end}}
+}
+{{end}}
+// {{.Name}} calls {{.HTTPMethod}} {{.Route}}{{with .Summary}}, {{.}}{{end}}
+func (ci *ConnectionInfo) {{.Name}}(ctx context.Context{{range .Args}}, {{.}}{{end}}{{if .Args}} string{{end}}{{if .Params}}, params {{.ParamsType}}{{end}}) ([]byte, error) {
+	return ci.action(ctx, "{{.HTTPMethod}}", {{if .Params}}params{{else}}nil{{end}}{{range .Elem}}, {{.}}{{end}})
+}
+{{end}}{{end}}`))
+
+// generate returns the go source of the types, accessors and actions for the resources of doc, source is named in the header
+func generate(doc *apidoc, pkg string, source string, declared *declarations) ([]byte, error) {
+
+	resources, consts := collect(doc, declared)
+
+	actions := false
+	for _, r := range resources {
+		actions = actions || len(r.Actions) > 0
+	}
+
+	var buf bytes.Buffer
+	err := fileTemplate.Execute(&buf, struct {
+		Source    string
+		Package   string
+		Actions   bool
+		Consts    []constDef
+		Resources []resourceDef
+	}{filepath.ToSlash(source), pkg, actions, consts, resources})
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// collect returns the collections of doc that can be listed and shown, sorted by name, and the path constants they need.
+// Collections whose path, type or accessor the package already declares are skipped
+func collect(doc *apidoc, declared *declarations) ([]resourceDef, []constDef) {
+
+	ids := make([]string, 0, len(doc.Docs.Resources))
+	for id := range doc.Docs.Resources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	consts := &constSet{declared: declared, names: map[string]string{}}
+
+	var defs []resourceDef
+	for _, id := range ids {
+
+		res := doc.Docs.Resources[id]
+
+		index, ok := res.method("index")
+		if !ok {
+			continue
+		}
+		collection, ok := index.collection(id)
+		if !ok {
+			continue
+		}
+		show, ok := res.method("show")
+		if !ok || !show.serves("GET", collection+"/:id") {
+			continue
+		}
+		if _, ok := declared.paths[strings.TrimPrefix(collection, "/")]; ok {
+			continue
+		}
+
+		wrapper, found := singular(id), false
+		var create, update []apiParam
+		for _, name := range []string{"create", "update"} {
+			m, ok := res.method(name)
+			if !ok {
+				continue
+			}
+			p, ok := wrapped(m, wrapper)
+			if !ok || (found && p.Name != wrapper) {
+				continue
+			}
+			wrapper, found = p.Name, true
+			if name == "create" {
+				create = p.Params
+			} else {
+				update = p.Params
+			}
+		}
+		params := merge(create, update)
+
+		def := resourceDef{
+			Collection: id,
+			Wrapper:    wrapper,
+			Type:       camel(wrapper),
+			Accessor:   camel(id) + "Resource",
+			Fields:     fields(params, true),
+		}
+		if declared.names[def.Type] || declared.names[def.Accessor] {
+			continue
+		}
+		def.Elem, def.Args = consts.route(collection, collection)
+
+		for _, m := range res.Methods {
+			if a, ok := action(def, collection, m); ok && !declared.names[a.Name] && !declared.names[a.ParamsType()] {
+				a.Elem, a.Args = consts.route(collection, a.Route)
+				def.Actions = append(def.Actions, a)
+			}
+		}
+
+		defs = append(defs, def)
+	}
+
+	return defs, consts.defs
+}
+
+// action returns the definition of an action of the resource served under its collection,
+// members actions are named after the type, e.g. WebhookTest, collection actions after the collection, e.g. WebhooksEvents
+func action(res resourceDef, collection string, m apiMethod) (actionDef, bool) {
+
+	switch m.Name {
+	case "index", "show", "create", "update", "destroy":
+		return actionDef{}, false
+	}
+	if len(m.Apis) == 0 {
+		return actionDef{}, false
+	}
+	api := m.Apis[0]
+
+	var name string
+	switch {
+	case strings.HasPrefix(api.APIURL, collection+"/:id/") || api.APIURL == collection+"/:id":
+		name = res.Type + camel(m.Name)
+	case strings.HasPrefix(api.APIURL, collection+"/") || api.APIURL == collection:
+		name = camel(res.Collection) + camel(m.Name)
+	default:
+		return actionDef{}, false
+	}
+
+	route := map[string]bool{}
+	for _, seg := range strings.Split(api.APIURL, "/") {
+		if strings.HasPrefix(seg, ":") {
+			route[seg[1:]] = true
+		}
+	}
+	var params []apiParam
+	for _, p := range m.Params {
+		if !route[p.Name] {
+			params = append(params, p)
+		}
+	}
+
+	return actionDef{
+		Name:       name,
+		HTTPMethod: api.HTTPMethod,
+		Route:      api.APIURL,
+		Summary:    lowerFirst(api.ShortDescription),
+		Params:     fields(params, false),
+	}, true
+}
+
+// constSet names the path constants of the routes, reusing the ones the package declares
+type constSet struct {
+	declared *declarations
+	names    map[string]string
+	defs     []constDef
+}
+
+// route returns the elements passed to apiURL for a route under the collection and the arguments its parameters are passed as,
+// e.g. usersapi, userID, "ssh_keys" and userID for /api/users/:user_id/ssh_keys
+func (c *constSet) route(collection string, route string) ([]string, []string) {
+
+	prefix := strings.Trim(collection, "/")
+	if i := strings.Index(prefix, "/:"); i >= 0 {
+		prefix = prefix[:i]
+	}
+
+	elem, args := []string{c.name(prefix)}, []string(nil)
+	for _, seg := range strings.Split(strings.Trim(strings.TrimPrefix(strings.Trim(route, "/"), prefix), "/"), "/") {
+		switch {
+		case seg == "":
+		case strings.HasPrefix(seg, ":"):
+			arg := lowerCamel(seg[1:])
+			elem, args = append(elem, arg), append(args, arg)
+		default:
+			elem = append(elem, strconv.Quote(seg))
+		}
+	}
+
+	return elem, args
+}
+
+// name returns the constant holding the path, declaring it the first time it is needed.
+// Constants are named after the last element, e.g. webhooksapi, or after every element when that name is taken
+func (c *constSet) name(path string) string {
+
+	if name, ok := c.declared.paths[path]; ok {
+		return name
+	}
+	if name, ok := c.names[path]; ok {
+		return name
+	}
+
+	name := strings.ReplaceAll(path[strings.LastIndex(path, "/")+1:], "_", "") + "api"
+	if c.taken(name) {
+		name = strings.NewReplacer("/", "", "_", "").Replace(path) + "api"
+	}
+
+	c.names[path] = name
+	c.defs = append(c.defs, constDef{Name: name, Path: path})
+
+	return name
+}
+
+// taken reports whether the constant name is declared by the package or already generated
+func (c *constSet) taken(name string) bool {
+
+	if c.declared.names[name] {
+		return true
+	}
+	for _, d := range c.defs {
+		if d.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapped returns the hash parameter the action wraps the resource in, preferring the one named after it
+func wrapped(m apiMethod, name string) (apiParam, bool) {
+
+	var hashes []apiParam
+	for _, p := range m.Params {
+		if p.ExpectedType == "hash" && len(p.Params) > 0 {
+			if p.Name == name {
+				return p, true
+			}
+			hashes = append(hashes, p)
+		}
+	}
+
+	if len(hashes) == 1 {
+		return hashes[0], true
+	}
+	return apiParam{}, false
+}
+
+// merge returns the parameters of create followed by those only update has, so each field has one type whichever
+// action documents it. Parameters are only required when create requires them, update never requires a field
+func merge(create []apiParam, update []apiParam) []apiParam {
+
+	params := append([]apiParam{}, create...)
+	seen := map[string]bool{}
+	for _, p := range create {
+		seen[p.Name] = true
+	}
+	for _, p := range update {
+		if !seen[p.Name] {
+			seen[p.Name] = true
+			p.Required = false
+			params = append(params, p)
+		}
+	}
+
+	return params
+}
+
+// fields returns the fields of a type with the parameters provided, resources are led by the id every one of them has.
+// Optional scalars are pointers so they can be set to their zero value and left out when they are nil
+func fields(params []apiParam, resource bool) []fieldDef {
+
+	var defs []fieldDef
+	seen := map[string]bool{}
+	if resource {
+		defs = append(defs, fieldDef{Name: "ID", Type: "int", JSON: "id"})
+		seen["id"] = true
+	}
+
+	for _, p := range params {
+		if seen[p.Name] {
+			continue
+		}
+		seen[p.Name] = true
+		typ := goType(p)
+		if !p.Required && !strings.HasPrefix(typ, "[]") && !strings.HasPrefix(typ, "map[") {
+			typ = "*" + typ
+		}
+		defs = append(defs, fieldDef{Name: camel(p.Name), Type: typ, JSON: p.Name})
+	}
+
+	// the name no action documents is optional like any other field create doesn't require
+	if resource && !seen["name"] {
+		defs = append(defs[:1], append([]fieldDef{{Name: "Name", Type: "*string", JSON: "name"}}, defs[1:]...)...)
+	}
+
+	return defs
+}
+
+// goType returns the go type of the values of the parameter
+func goType(p apiParam) string {
+
+	switch p.ExpectedType {
+	case "numeric":
+		if strings.Contains(strings.ToLower(p.Validator), "float") {
+			return "float64"
+		}
+		return "int"
+	case "boolean":
+		return "bool"
+	case "array":
+		if strings.HasSuffix(p.Name, "_ids") {
+			return "[]int"
+		}
+		return "[]string"
+	case "hash":
+		return "map[string]interface{}"
+	default:
+		return "string"
+	}
+}
+
+// camel returns the exported go identifier of the snake case name, e.g. auth_source_ldaps becomes AuthSourceLDAPs
+func camel(name string) string {
+
+	var b strings.Builder
+	for _, word := range strings.Split(name, "_") {
+		if word == "" {
+			continue
+		}
+		upper := strings.ToUpper(word)
+		switch {
+		case initialisms[upper]:
+			b.WriteString(upper)
+		case strings.HasSuffix(word, "s") && initialisms[upper[:len(upper)-1]]:
+			b.WriteString(upper[:len(upper)-1] + "s")
+		default:
+			b.WriteString(upper[:1] + word[1:])
+		}
+	}
+
+	return b.String()
+}
+
+// lowerCamel returns the unexported go identifier of the snake case name, e.g. compute_resource_id becomes computeResourceID
+func lowerCamel(name string) string {
+
+	first, rest := name, ""
+	if i := strings.Index(name, "_"); i >= 0 {
+		first, rest = name[:i], name[i+1:]
+	}
+
+	return strings.ToLower(first) + camel(rest)
+}
+
+// lowerFirst returns the text with its first letter in lower case, unless it starts an initialism, e.g. List all hosts
+func lowerFirst(text string) string {
+
+	word := text
+	if i := strings.IndexAny(text, " ,"); i >= 0 {
+		word = text[:i]
+	}
+	if word == "" || initialisms[strings.ToUpper(word)] || strings.ToUpper(word) == word {
+		return text
+	}
+
+	return strings.ToLower(text[:1]) + text[1:]
+}
+
+// singular returns the singular of the collection name, e.g. http_proxies becomes http_proxy
+func singular(name string) string {
+
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"):
+		return strings.TrimSuffix(name, "es")
+	default:
+		return strings.TrimSuffix(name, "s")
+	}
+}
+
+// readDeclarations returns the top level identifiers, the methods and the string constants declared by the go files in dir,
+// except for the tests and the file being generated
+func readDeclarations(dir string, out string) (*declarations, error) {
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != filepath.Base(out)
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	declared := &declarations{names: map[string]bool{}, paths: map[string]string{}}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				switch d := decl.(type) {
+				case *ast.FuncDecl:
+					declared.names[d.Name.Name] = true
+				case *ast.GenDecl:
+					for _, spec := range d.Specs {
+						switch s := spec.(type) {
+						case *ast.TypeSpec:
+							declared.names[s.Name.Name] = true
+						case *ast.ValueSpec:
+							for i, n := range s.Names {
+								declared.names[n.Name] = true
+								if d.Tok != token.CONST || i >= len(s.Values) {
+									continue
+								}
+								if lit, ok := s.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+									if path, err := strconv.Unquote(lit.Value); err == nil {
+										declared.paths[path] = n.Name
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return declared, nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCamel(t *testing.T) {
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"http_proxy", "HTTPProxy"},
+		{"auth_source_ldaps", "AuthSourceLDAPs"},
+		{"location_ids", "LocationIDs"},
+		{"operatingsystem_id", "OperatingsystemID"},
+		{"base_dn", "BaseDN"},
+		{"name", "Name"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := camel(tc.name); got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestLowerCamel(t *testing.T) {
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"id", "id"},
+		{"user_id", "userID"},
+		{"compute_resource_id", "computeResourceID"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := lowerCamel(tc.name); got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+
+	doc, err := readAPIDoc("../../pkg/foreman/apidoc/v2.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	declared, err := readDeclarations("../../pkg/foreman", "generated.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := generate(doc, "foreman", "apidoc/v2.json", declared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(src)
+
+	tests := []struct {
+		name     string
+		contains string
+		want     bool
+	}{
+		{"type from create parameters", "type HTTPProxy struct", true},
+		{"array of ids", "LocationIDs []int `json:\"location_ids,omitempty\"`", true},
+		{"accessor", "func (ci *ConnectionInfo) WebhooksResource() *Resource[Webhook]", true},
+		{"read only collection", "type MailNotification struct", true},
+		{"optional field pointer", "Enabled *bool `json:\"enabled,omitempty\"`", true},
+		{"update only name pointer", "type AuthSourceExternal struct { ID int `json:\"id,omitempty\"` Name *string `json:\"name,omitempty\"`", true},
+		{"undocumented name pointer", "type AuthSourceInternal struct { ID int `json:\"id,omitempty\"` Name *string `json:\"name,omitempty\"`", true},
+		{"required field value", "TargetURL string `json:\"target_url,omitempty\"`", true},
+		{"nested collection", "func (ci *ConnectionInfo) SSHKeysResource(userID string) *Resource[SSHKey] { return NewResource[SSHKey](ci, \"ssh_key\", usersapi, userID, \"ssh_keys\")", true},
+		{"member action", "func (ci *ConnectionInfo) WebhookTest(ctx context.Context, id string, params WebhookTestParams) ([]byte, error) { return ci.action(ctx, \"POST\", params, webhooksapi, id, \"test\")", true},
+		{"collection action", "func (ci *ConnectionInfo) WebhooksEvents(ctx context.Context) ([]byte, error) { return ci.action(ctx, \"GET\", nil, webhooksapi, \"events\")", true},
+		{"action params", "Payload *string `json:\"payload,omitempty\"`", true},
+		{"taken constant", "apitemplatesapi = \"api/templates\"", true},
+		{"hand written type", "type Host struct", false},
+		{"hand written path", "SubnetsResource", false},
+		{"hand written action", "func (ci *ConnectionInfo) HostPower(", false},
+		{"collection without show", "Statistic", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := strings.Contains(strings.Join(strings.Fields(out), " "), strings.Join(strings.Fields(tc.contains), " ")); got != tc.want {
+				t.Errorf("expected contains %q to be %v\n%s", tc.contains, tc.want, out)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+
+	create := []apiParam{{Name: "name", ExpectedType: "string", Required: true}, {Name: "url", ExpectedType: "string"}}
+	update := []apiParam{{Name: "url", ExpectedType: "numeric", Required: true}, {Name: "enabled", ExpectedType: "boolean", Required: true}}
+
+	tests := []struct {
+		name   string
+		create []apiParam
+		update []apiParam
+		want   string
+	}{
+		{"create and update", create, update, "Name string,URL *string,Enabled *bool"},
+		{"update only", nil, update, "URL *int,Enabled *bool"},
+		{"create only", create, nil, "Name string,URL *string"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, f := range fields(merge(tc.create, tc.update), false) {
+				got = append(got, f.Name+" "+f.Type)
+			}
+			if strings.Join(got, ",") != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, strings.Join(got, ","))
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Command foreman-gen generates the typed resources of package foreman from the apipie description of the foreman api.
+
+	foreman-gen -in apidoc/v2.json -out generated.go
+
+Every collection that can be listed and shown gets a type with the fields of its create parameters, optional ones as pointers,
+an accessor returning a Resource, taking the ids of the parents of nested collections, and a method for each of its other actions.
+Collections whose path, type or accessor the package already declares by hand are left alone.
+The checked in snapshot was reconstructed offline, refresh it from a foreman server, including its plugins, with
+
+	curl -u admin https://foreman.example.com/apidoc/v2.json > apidoc/v2.json
+*/
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+)
+
+func main() {
+
+	in := flag.String("in", "apidoc/v2.json", "apipie json describing the foreman api")
+	out := flag.String("out", "generated.go", "go file to write")
+	pkg := flag.String("package", "foreman", "package of the generated file")
+	flag.Parse()
+
+	doc, err := readAPIDoc(*in)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+
+	declared, err := readDeclarations(filepath.Dir(*out), *out)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+
+	src, err := generate(doc, *pkg, *in, declared)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+
+	err = ioutil.WriteFile(*out, src, 0644)
+	if err != nil {
+		log.Fatalf("Error: %s", err.Error())
+	}
+}
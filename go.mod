@@ -1,5 +1,5 @@
 module github.com/bishy999/go-foreman
 
-go 1.13
+go 1.18
 
 require github.com/golangci/golangci-lint v1.23.7 // indirect
@@ -0,0 +1,190 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	ansiblerolesapi     = "api/ansible_roles"
+	ansiblevariablesapi = "api/ansible_variables"
+	ansibleoverridesapi = "api/ansible_override_values"
+)
+
+// AnsibleRole represents an ansible role imported into foreman
+type AnsibleRole struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// AnsibleVariable represents a variable of an ansible role that can be overridden by matchers
+type AnsibleVariable struct {
+	ID              int             `json:"id,omitempty"`
+	Variable        string          `json:"variable,omitempty"`
+	AnsibleRoleID   int             `json:"ansible_role_id,omitempty"`
+	AnsibleRoleName string          `json:"ansible_role,omitempty"`
+	VariableType    string          `json:"variable_type,omitempty"`
+	Description     string          `json:"description,omitempty"`
+	Override        *bool           `json:"override,omitempty"`
+	DefaultValue    interface{}     `json:"default_value,omitempty"`
+	OverrideValues  []OverrideValue `json:"override_values,omitempty"`
+}
+
+// ansiblePlayResp contains the job invocation started to play ansible roles
+type ansiblePlayResp struct {
+	JobInvocation JobInvocation `json:"job_invocation"`
+}
+
+// AnsibleRolesResource returns the typed read only resource for ansible roles, they are imported from a smart proxy
+func (ci *ConnectionInfo) AnsibleRolesResource() *ReadOnlyResource[AnsibleRole] {
+	return NewReadOnlyResource[AnsibleRole](ci, ansiblerolesapi)
+}
+
+// AnsibleVariablesResource returns the typed resource for ansible variables
+func (ci *ConnectionInfo) AnsibleVariablesResource() *Resource[AnsibleVariable] {
+	return NewResource[AnsibleVariable](ci, "ansible_variable", ansiblevariablesapi)
+}
+
+// ListAnsibleRoles returns the ansible roles matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListAnsibleRoles(ctx context.Context, search string) ([]AnsibleRole, error) {
+	return ci.AnsibleRolesResource().List(ctx, search)
+}
+
+// ResolveAnsibleRole returns the id of the ansible role with the name provided
+func (ci *ConnectionInfo) ResolveAnsibleRole(ctx context.Context, name string) (int, error) {
+	return ci.AnsibleRolesResource().Resolve(ctx, name)
+}
+
+// ImportAnsibleRoles imports the ansible roles found on the smart proxy
+func (ci *ConnectionInfo) ImportAnsibleRoles(ctx context.Context, proxyID int) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	req := map[string]int{"proxy_id": proxyID}
+	err := ci.doJSON(ctx, http.MethodPut, ci.apiURL(nil, ansiblerolesapi, "import"), req, &result)
+	return result, err
+}
+
+// ListHostAnsibleRoles returns the ansible roles assigned directly to the host
+func (ci *ConnectionInfo) ListHostAnsibleRoles(ctx context.Context) ([]AnsibleRole, error) {
+	var roles []AnsibleRole
+	err := ci.doJSON(ctx, http.MethodGet, ci.apiURL(nil, hostsapi, ci.Hostname, "ansible_roles"), nil, &roles)
+	return roles, err
+}
+
+// AssignHostAnsibleRoles replaces the ansible roles of the host with the ones provided
+func (ci *ConnectionInfo) AssignHostAnsibleRoles(ctx context.Context, roleIDs []int) error {
+	req := map[string][]int{"ansible_role_ids": roleIDs}
+	return ci.doJSON(ctx, http.MethodPost, ci.apiURL(nil, hostsapi, ci.Hostname, "assign_ansible_roles"), req, nil)
+}
+
+// PlayHostAnsibleRoles runs the ansible roles of the host and returns the job invocation started
+func (ci *ConnectionInfo) PlayHostAnsibleRoles(ctx context.Context) (*JobInvocation, error) {
+	var resp ansiblePlayResp
+	err := ci.doJSON(ctx, http.MethodPost, ci.apiURL(nil, hostsapi, ci.Hostname, "play_roles"), nil, &resp)
+	return &resp.JobInvocation, err
+}
+
+// ListHostgroupAnsibleRoles returns the ansible roles assigned to the hostgroup
+func (ci *ConnectionInfo) ListHostgroupAnsibleRoles(ctx context.Context, hostgroupID int) ([]AnsibleRole, error) {
+	var roles []AnsibleRole
+	err := ci.doJSON(ctx, http.MethodGet, ci.apiURL(nil, hostgroupsapi, strconv.Itoa(hostgroupID), "ansible_roles"), nil, &roles)
+	return roles, err
+}
+
+// AssignHostgroupAnsibleRoles replaces the ansible roles of the hostgroup with the ones provided
+func (ci *ConnectionInfo) AssignHostgroupAnsibleRoles(ctx context.Context, hostgroupID int, roleIDs []int) error {
+	req := map[string][]int{"ansible_role_ids": roleIDs}
+	return ci.doJSON(ctx, http.MethodPost, ci.apiURL(nil, hostgroupsapi, strconv.Itoa(hostgroupID), "assign_ansible_roles"), req, nil)
+}
+
+// PlayHostgroupAnsibleRoles runs the ansible roles on every host of the hostgroup and returns the job invocation started
+func (ci *ConnectionInfo) PlayHostgroupAnsibleRoles(ctx context.Context, hostgroupID int) (*JobInvocation, error) {
+	var resp ansiblePlayResp
+	err := ci.doJSON(ctx, http.MethodPost, ci.apiURL(nil, hostgroupsapi, strconv.Itoa(hostgroupID), "play_roles"), nil, &resp)
+	return &resp.JobInvocation, err
+}
+
+// ResolveHostgroup returns the id of the hostgroup with the name or title provided
+func (ci *ConnectionInfo) ResolveHostgroup(ctx context.Context, name string) (int, error) {
+	return ci.HostgroupsResource().Resolve(ctx, name)
+}
+
+// ListAnsibleVariables returns the ansible variables matching the search, e.g. ansible_role = nginx
+func (ci *ConnectionInfo) ListAnsibleVariables(ctx context.Context, search string) ([]AnsibleVariable, error) {
+	return ci.AnsibleVariablesResource().List(ctx, search)
+}
+
+// GetAnsibleVariable returns the ansible variable, including its override values, with the id provided
+func (ci *ConnectionInfo) GetAnsibleVariable(ctx context.Context, id int) (*AnsibleVariable, error) {
+	return ci.AnsibleVariablesResource().Get(ctx, strconv.Itoa(id))
+}
+
+// CreateAnsibleVariable creates a new ansible variable
+func (ci *ConnectionInfo) CreateAnsibleVariable(ctx context.Context, variable AnsibleVariable) (*AnsibleVariable, error) {
+	return ci.AnsibleVariablesResource().Create(ctx, variable)
+}
+
+// UpdateAnsibleVariable updates the ansible variable with the id provided, set Override to allow override values
+func (ci *ConnectionInfo) UpdateAnsibleVariable(ctx context.Context, id int, variable AnsibleVariable) (*AnsibleVariable, error) {
+	return ci.AnsibleVariablesResource().Update(ctx, strconv.Itoa(id), variable)
+}
+
+// DeleteAnsibleVariable deletes the ansible variable with the id provided
+func (ci *ConnectionInfo) DeleteAnsibleVariable(ctx context.Context, id int) error {
+	return ci.AnsibleVariablesResource().Delete(ctx, strconv.Itoa(id))
+}
+
+// ResolveAnsibleVariable returns the id of the ansible variable with the name provided
+func (ci *ConnectionInfo) ResolveAnsibleVariable(ctx context.Context, name string) (int, error) {
+
+	if id, err := strconv.Atoi(name); err == nil {
+		return id, nil
+	}
+
+	vars, err := ci.ListAnsibleVariables(ctx, fmt.Sprintf("key = %q", name))
+	if err != nil {
+		return 0, err
+	}
+	for _, v := range vars {
+		if v.Variable == name {
+			return v.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("ansible variable [%s] %w", name, ErrNotFound)
+}
+
+// SetAnsibleOverrideValue creates or replaces the override value of the ansible variable for the matcher provided
+func (ci *ConnectionInfo) SetAnsibleOverrideValue(ctx context.Context, variableID int, match string, value interface{}) (*OverrideValue, error) {
+
+	variable, err := ci.GetAnsibleVariable(ctx, variableID)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range variable.OverrideValues {
+		if v.Match == match {
+			err = ci.DeleteAnsibleOverrideValue(ctx, v.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	req := map[string]interface{}{
+		"ansible_variable_id": variableID,
+		"override_value":      OverrideValue{Match: match, Value: value},
+	}
+
+	var created OverrideValue
+	err = ci.doJSON(ctx, http.MethodPost, ci.apiURL(nil, ansibleoverridesapi), req, &created)
+
+	return &created, err
+}
+
+// DeleteAnsibleOverrideValue deletes the ansible override value with the id provided
+func (ci *ConnectionInfo) DeleteAnsibleOverrideValue(ctx context.Context, id int) error {
+	return ci.doJSON(ctx, http.MethodDelete, ci.apiURL(nil, ansibleoverridesapi, strconv.Itoa(id)), nil, nil)
+}
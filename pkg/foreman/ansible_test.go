@@ -0,0 +1,122 @@
+package foreman_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	ansibleTimeout = 180
+)
+
+func ExampleConnectionInfo_PlayHostAnsibleRoles() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(`{"job_invocation":{"id":31,"description":"Run ansible roles","status_label":"queued","pending":1}}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, ansibleTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client(), Hostname: "test"}
+	job, err := api.PlayHostAnsibleRoles(ctx)
+
+	fmt.Printf("%d %s %v", job.ID, job.StatusLabel, err)
+
+	// Output: 31 queued <nil>
+}
+
+func TestAssignAnsibleRoles(t *testing.T) {
+
+	var request, body string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		data, _ := ioutil.ReadAll(req.Body)
+		request, body = req.Method+" "+req.URL.Path, string(data)
+		check(rw.Write([]byte(`{}`)))
+	}))
+	defer server.Close()
+
+	tt := []struct {
+		name           string
+		call           func(*foreman.ConnectionInfo, context.Context) error
+		expectedresult string
+	}{
+		{
+			name: "host",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) error {
+				return ci.AssignHostAnsibleRoles(ctx, []int{1, 2})
+			},
+			expectedresult: "POST /api/hosts/test/assign_ansible_roles",
+		},
+		{
+			name: "hostgroup",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) error {
+				return ci.AssignHostgroupAnsibleRoles(ctx, 4, []int{1, 2})
+			},
+			expectedresult: "POST /api/hostgroups/4/assign_ansible_roles",
+		},
+	}
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, ansibleTimeout*time.Second)
+	defer cancel()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client(), Hostname: "test"}
+			err := tc.call(&api, ctx)
+			if err != nil {
+				t.Fatalf("Could not read response %v correctly", err)
+			}
+			if tc.expectedresult != request || body != `{"ansible_role_ids":[1,2]}` {
+				t.Errorf("Test %v result should be %v, got  `%v %v`", tc.name, tc.expectedresult, request, body)
+			}
+		})
+	}
+}
+
+func TestSetAnsibleOverrideValue(t *testing.T) {
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		data, _ := ioutil.ReadAll(req.Body)
+		requests = append(requests, req.Method+" "+req.URL.Path+" "+string(data))
+		switch req.Method {
+		case http.MethodGet:
+			check(rw.Write([]byte(`{"id":6,"variable":"nginx_port","override_values":[{"id":2,"match":"fqdn=test","value":80}]}`)))
+		default:
+			check(rw.Write([]byte(`{"id":3,"match":"fqdn=test","value":"8080"}`)))
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, ansibleTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	value, err := api.SetAnsibleOverrideValue(ctx, 6, "fqdn=test", "8080")
+	if err != nil {
+		t.Fatalf("Could not read response %v correctly", err)
+	}
+
+	expected := []string{
+		"GET /api/ansible_variables/6 ",
+		"DELETE /api/ansible_override_values/2 ",
+		`POST /api/ansible_override_values {"ansible_variable_id":6,"override_value":{"match":"fqdn=test","value":"8080"}}`,
+	}
+	if fmt.Sprint(expected) != fmt.Sprint(requests) || value.ID != 3 {
+		t.Errorf("requests should be %v, got `%v`", expected, requests)
+	}
+	log.Printf("Response: %v", value)
+}
@@ -0,0 +1,101 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	auditsapi       = "api/audits"
+	auditTimeLayout = "2006-01-02 15:04:05"
+)
+
+// Audit represents a change to a foreman resource recorded in the audit log
+type Audit struct {
+	ID             int                    `json:"id"`
+	UserID         int                    `json:"user_id"`
+	UserName       string                 `json:"user_name"`
+	Action         string                 `json:"action"`
+	AuditableType  string                 `json:"auditable_type"`
+	AuditableID    int                    `json:"auditable_id"`
+	AuditableName  string                 `json:"auditable_name"`
+	AuditedChanges map[string]interface{} `json:"audited_changes"`
+	Comment        string                 `json:"comment"`
+	RemoteAddress  string                 `json:"remote_address"`
+	RequestUUID    string                 `json:"request_uuid"`
+	Version        int                    `json:"version"`
+	CreatedAt      string                 `json:"created_at"`
+}
+
+// AuditFilter contains the criteria used to build an audit search query, empty fields are ignored.
+// Host matches the audited name of host audits so changes of deleted hosts are found too.
+// Query is added as is, e.g. the query of a bookmark
+type AuditFilter struct {
+	Host         string
+	ResourceType string
+	User         string
+	Action       string
+	Since        time.Time
+	Until        time.Time
+	Query        string
+}
+
+// Search returns the foreman search query for the filter
+func (f AuditFilter) Search() string {
+
+	var terms []string
+
+	if f.Host != "" {
+		// host = joins the hosts table which no longer has the deleted hosts
+		terms = append(terms, fmt.Sprintf("type = host and name = %q", f.Host))
+	}
+	if f.ResourceType != "" {
+		terms = append(terms, fmt.Sprintf("type = %q", f.ResourceType))
+	}
+	if f.User != "" {
+		terms = append(terms, fmt.Sprintf("user = %q", f.User))
+	}
+	if f.Action != "" {
+		terms = append(terms, fmt.Sprintf("action = %q", f.Action))
+	}
+	if !f.Since.IsZero() {
+		terms = append(terms, fmt.Sprintf("time >= %q", f.Since.UTC().Format(auditTimeLayout)))
+	}
+	if !f.Until.IsZero() {
+		terms = append(terms, fmt.Sprintf("time <= %q", f.Until.UTC().Format(auditTimeLayout)))
+	}
+
+	if f.Query != "" {
+		terms = append(terms, "("+f.Query+")")
+	}
+
+	return strings.Join(terms, " and ")
+}
+
+// CreatedTime returns the time the change was made
+func (a *Audit) CreatedTime() (time.Time, error) {
+
+	t, err := time.Parse(reportTimeLayout, a.CreatedAt)
+	if err != nil {
+		return time.Parse(time.RFC3339, a.CreatedAt)
+	}
+
+	return t, nil
+}
+
+// AuditsResource returns the typed read only resource for audits
+func (ci *ConnectionInfo) AuditsResource() *ReadOnlyResource[Audit] {
+	return NewReadOnlyResource[Audit](ci, auditsapi)
+}
+
+// ListAudits returns the audits matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListAudits(ctx context.Context, search string) ([]Audit, error) {
+	return ci.AuditsResource().List(ctx, search)
+}
+
+// FilterAudits returns the audits matching the filter
+func (ci *ConnectionInfo) FilterAudits(ctx context.Context, filter AuditFilter) ([]Audit, error) {
+	return ci.ListAudits(ctx, filter.Search())
+}
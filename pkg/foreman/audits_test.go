@@ -0,0 +1,88 @@
+package foreman_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	auditsTimeout = 180
+)
+
+func ExampleConnectionInfo_FilterAudits() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		log.Printf("Search: %s", req.URL.Query().Get("search"))
+		check(rw.Write([]byte(`{"total":1,"subtotal":1,"page":1,"per_page":100,"results":[{"id":9,"user_name":"jenkins","action":"destroy","auditable_type":"Host::Base","auditable_name":"host1","created_at":"2020-03-01 10:00:00 UTC"}]}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, auditsTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	audits, err := api.FilterAudits(ctx, foreman.AuditFilter{Host: "host1", Action: "destroy"})
+
+	fmt.Printf("%s %s %s %v", audits[0].AuditableName, audits[0].Action, audits[0].UserName, err)
+
+	// Output: host1 destroy jenkins <nil>
+}
+
+func TestAuditFilterSearch(t *testing.T) {
+
+	since := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name           string
+		filter         foreman.AuditFilter
+		expectedresult string
+	}{
+		{name: "empty", filter: foreman.AuditFilter{}, expectedresult: ""},
+		{name: "host since", filter: foreman.AuditFilter{Host: "host1", Since: since}, expectedresult: `type = host and name = "host1" and time >= "2020-03-01 10:00:00"`},
+		{name: "bookmark query", filter: foreman.AuditFilter{Action: "destroy", Query: "host ~ dev or host ~ test"}, expectedresult: `action = "destroy" and (host ~ dev or host ~ test)`},
+		{name: "all fields", filter: foreman.AuditFilter{ResourceType: "host", User: "admin", Action: "destroy", Until: since}, expectedresult: `type = "host" and user = "admin" and action = "destroy" and time <= "2020-03-01 10:00:00"`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			search := tc.filter.Search()
+			if tc.expectedresult != search {
+				t.Errorf("Test %v result should be %v, got  `%v`", tc.name, tc.expectedresult, search)
+			}
+		})
+	}
+}
+
+func TestFilterAuditsDeletedHost(t *testing.T) {
+
+	// the host is gone so only its audits still know the name
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("search") != `type = host and name = "host1"` {
+			check(rw.Write([]byte(`{"total":0,"subtotal":0,"page":1,"per_page":100,"results":[]}`)))
+			return
+		}
+		check(rw.Write([]byte(`{"total":2,"subtotal":2,"page":1,"per_page":100,"results":[{"id":8,"action":"create","auditable_type":"Host::Base","auditable_name":"host1"},{"id":9,"action":"destroy","auditable_type":"Host::Base","auditable_name":"host1"}]}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, auditsTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	audits, err := api.FilterAudits(ctx, foreman.AuditFilter{Host: "host1"})
+	if err != nil {
+		t.Fatalf("Could not filter audits %v", err)
+	}
+	if len(audits) != 2 || audits[1].Action != "destroy" {
+		t.Errorf("Test deleted host result should be the create and destroy audits, got  `%v`", audits)
+	}
+}
@@ -0,0 +1,77 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+const (
+	bookmarksapi = "api/bookmarks"
+)
+
+// Bookmark represents a saved search, controller is the listing it applies to such as hosts or audits
+type Bookmark struct {
+	ID         int    `json:"id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Controller string `json:"controller,omitempty"`
+	Query      string `json:"query,omitempty"`
+	Public     *bool  `json:"public,omitempty"`
+	OwnerID    int    `json:"owner_id,omitempty"`
+	OwnerType  string `json:"owner_type,omitempty"`
+}
+
+// BookmarksResource returns the typed resource for bookmarks
+func (ci *ConnectionInfo) BookmarksResource() *Resource[Bookmark] {
+	return NewResource[Bookmark](ci, "bookmark", bookmarksapi)
+}
+
+// ListBookmarks returns the bookmarks matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListBookmarks(ctx context.Context, search string) ([]Bookmark, error) {
+	return ci.BookmarksResource().List(ctx, search)
+}
+
+// GetBookmark returns the bookmark with the id provided
+func (ci *ConnectionInfo) GetBookmark(ctx context.Context, id int) (*Bookmark, error) {
+	return ci.BookmarksResource().Get(ctx, strconv.Itoa(id))
+}
+
+// CreateBookmark creates a new bookmark
+func (ci *ConnectionInfo) CreateBookmark(ctx context.Context, bookmark Bookmark) (*Bookmark, error) {
+	return ci.BookmarksResource().Create(ctx, bookmark)
+}
+
+// UpdateBookmark updates the bookmark with the id provided
+func (ci *ConnectionInfo) UpdateBookmark(ctx context.Context, id int, bookmark Bookmark) (*Bookmark, error) {
+	return ci.BookmarksResource().Update(ctx, strconv.Itoa(id), bookmark)
+}
+
+// DeleteBookmark deletes the bookmark with the id provided
+func (ci *ConnectionInfo) DeleteBookmark(ctx context.Context, id int) error {
+	return ci.BookmarksResource().Delete(ctx, strconv.Itoa(id))
+}
+
+// ResolveBookmark returns the query stored in the bookmark with the name provided, an empty controller matches any
+func (ci *ConnectionInfo) ResolveBookmark(ctx context.Context, name string, controller string) (string, error) {
+
+	bookmarks, err := ci.ListBookmarks(ctx, fmt.Sprintf("name = %q", name))
+	if err != nil {
+		return "", err
+	}
+
+	var matches []Bookmark
+	for _, b := range bookmarks {
+		if b.Name == name && (controller == "" || b.Controller == controller) {
+			matches = append(matches, b)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("bookmark [%s] %w", name, ErrNotFound)
+	case 1:
+		return matches[0].Query, nil
+	default:
+		return "", fmt.Errorf("bookmark [%s] %w, %d matches found", name, ErrAmbiguous, len(matches))
+	}
+}
@@ -0,0 +1,77 @@
+package foreman_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	bookmarksTimeout = 180
+	bookmarks        = `{"total":3,"subtotal":2,"page":1,"per_page":100,"results":[
+{"id":1,"name":"dev hosts","controller":"hosts","query":"name ~ dev","public":true},
+{"id":2,"name":"dev hosts","controller":"audits","query":"host ~ dev","public":false}]}`
+)
+
+func ExampleConnectionInfo_ResolveBookmark() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(bookmarks)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, bookmarksTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	query, err := api.ResolveBookmark(ctx, "dev hosts", "hosts")
+
+	fmt.Printf("%s %v", query, err)
+
+	// Output: name ~ dev <nil>
+}
+
+func TestResolveBookmark(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(bookmarks)))
+	}))
+	defer server.Close()
+
+	tt := []struct {
+		name           string
+		bookmark       string
+		controller     string
+		expectedresult string
+		expectederr    bool
+		notfound       bool
+	}{
+		{name: "controller", bookmark: "dev hosts", controller: "audits", expectedresult: "host ~ dev"},
+		{name: "ambiguous", bookmark: "dev hosts", controller: "", expectederr: true},
+		{name: "not found", bookmark: "dev hosts", controller: "fact_values", expectederr: true, notfound: true},
+	}
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, bookmarksTimeout*time.Second)
+	defer cancel()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+			query, err := api.ResolveBookmark(ctx, tc.bookmark, tc.controller)
+			if tc.expectederr != (err != nil) || tc.notfound != errors.Is(err, foreman.ErrNotFound) {
+				t.Fatalf("Test %v error expected %v, got `%v`", tc.name, tc.expectederr, err)
+			}
+			if tc.expectedresult != query {
+				t.Errorf("Test %v result should be %v, got  `%v`", tc.name, tc.expectedresult, query)
+			}
+		})
+	}
+}
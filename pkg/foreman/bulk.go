@@ -0,0 +1,107 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+const (
+	bulkapi = "bulk"
+)
+
+// BulkHostResult contains the outcome of a bulk action for a single host
+type BulkHostResult struct {
+	ID      int
+	Name    string
+	Success bool
+}
+
+// bulkErrorResp contains the hosts foreman failed to update in a bulk action
+type bulkErrorResp struct {
+	Error struct {
+		Message       string `json:"message"`
+		FailedHostIDs []int  `json:"failed_host_ids"`
+	} `json:"error"`
+}
+
+// BulkDestroyHosts deletes every host matching the search
+func (ci *ConnectionInfo) BulkDestroyHosts(ctx context.Context, search string) ([]BulkHostResult, error) {
+	return ci.bulkAction(ctx, http.MethodDelete, search, nil)
+}
+
+// BulkReassignHostgroup moves every host matching the search to the hostgroup
+func (ci *ConnectionInfo) BulkReassignHostgroup(ctx context.Context, search string, hostgroupID int) ([]BulkHostResult, error) {
+	return ci.bulkAction(ctx, http.MethodPut, search, map[string]interface{}{"hostgroup_id": hostgroupID}, "reassign_hostgroup")
+}
+
+// BulkChangeOwner sets the owner of every host matching the search, ownerType is User or Usergroup
+func (ci *ConnectionInfo) BulkChangeOwner(ctx context.Context, search string, ownerID int, ownerType string) ([]BulkHostResult, error) {
+	return ci.bulkAction(ctx, http.MethodPut, search, map[string]interface{}{"owner_id": ownerID, "owner_type": ownerType}, "change_owner")
+}
+
+// BulkChangeEnvironment sets the puppet environment of every host matching the search
+func (ci *ConnectionInfo) BulkChangeEnvironment(ctx context.Context, search string, environmentID int) ([]BulkHostResult, error) {
+	return ci.bulkAction(ctx, http.MethodPut, search, map[string]interface{}{"environment_id": environmentID}, "change_environment")
+}
+
+// BulkBuild puts every host matching the search into build mode, optionally rebooting them
+func (ci *ConnectionInfo) BulkBuild(ctx context.Context, search string, reboot bool) ([]BulkHostResult, error) {
+	return ci.bulkAction(ctx, http.MethodPut, search, map[string]interface{}{"reboot": reboot}, "build")
+}
+
+// BulkPower sends a power action (on, off, soft, cycle, reset) to every host matching the search
+func (ci *ConnectionInfo) BulkPower(ctx context.Context, search string, action string) ([]BulkHostResult, error) {
+	return ci.bulkAction(ctx, http.MethodPut, search, map[string]interface{}{"power": action}, "change_power_state")
+}
+
+// bulkAction sends the search to the bulk endpoint so foreman selects the hosts itself, the hosts matching
+// the search are only listed to report the result of each, those foreman reports as failed are marked as unsuccessful
+func (ci *ConnectionInfo) bulkAction(ctx context.Context, method string, search string, params map[string]interface{}, action ...string) ([]BulkHostResult, error) {
+
+	if search == "" {
+		return nil, errors.New("a search is required for bulk actions")
+	}
+
+	var hosts []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	err := ci.list(ctx, search, &hosts, hostsapi)
+	if err != nil {
+		return nil, err
+	}
+	if len(hosts) == 0 {
+		return nil, nil
+	}
+
+	req := map[string]interface{}{"included": map[string]string{"search": search}}
+	for k, v := range params {
+		req[k] = v
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	failed := map[int]bool{}
+	body, err := ci.doRequest(ctx, method, ci.apiURL(nil, append([]string{hostsapi, bulkapi}, action...)...), data)
+	if err != nil {
+		var apiErr *APIError
+		var resp bulkErrorResp
+		if !errors.As(err, &apiErr) || json.Unmarshal(body, &resp) != nil || len(resp.Error.FailedHostIDs) == 0 {
+			return nil, err
+		}
+		for _, id := range resp.Error.FailedHostIDs {
+			failed[id] = true
+		}
+	}
+
+	results := make([]BulkHostResult, 0, len(hosts))
+	for _, h := range hosts {
+		results = append(results, BulkHostResult{ID: h.ID, Name: h.Name, Success: !failed[h.ID]})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,122 @@
+package foreman_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	bulkTimeout = 180
+	bulkHosts   = `{"total":3,"subtotal":2,"page":1,"per_page":100,"results":[{"id":1,"name":"dev1"},{"id":2,"name":"dev2"}]}`
+)
+
+func ExampleConnectionInfo_BulkReassignHostgroup() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodGet {
+			check(rw.Write([]byte(bulkHosts)))
+			return
+		}
+		check(rw.Write([]byte(`{"message":"Updated hosts: changed host group"}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, bulkTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	results, err := api.BulkReassignHostgroup(ctx, "name ~ dev", 4)
+
+	for _, r := range results {
+		fmt.Printf("%s %t ", r.Name, r.Success)
+	}
+	fmt.Printf("%v", err)
+
+	// Output: dev1 true dev2 true <nil>
+}
+
+func TestBulkActions(t *testing.T) {
+
+	tt := []struct {
+		name            string
+		call            func(*foreman.ConnectionInfo, context.Context) ([]foreman.BulkHostResult, error)
+		status          int
+		response        string
+		expectedrequest string
+		expectedresult  string
+		expectederr     bool
+	}{
+		{
+			name: "destroy",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) ([]foreman.BulkHostResult, error) {
+				return ci.BulkDestroyHosts(ctx, "name ~ dev")
+			},
+			status: http.StatusOK, response: `{"message":"Deleted 2 hosts"}`,
+			expectedrequest: `DELETE /api/hosts/bulk {"included":{"search":"name ~ dev"}}`, expectedresult: "[{1 dev1 true} {2 dev2 true}]",
+		},
+		{
+			name: "reassign hostgroup",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) ([]foreman.BulkHostResult, error) {
+				return ci.BulkReassignHostgroup(ctx, "name ~ dev", 4)
+			},
+			status: http.StatusOK, response: `{"message":"Updated hosts: changed host group"}`,
+			expectedrequest: `PUT /api/hosts/bulk/reassign_hostgroup {"hostgroup_id":4,"included":{"search":"name ~ dev"}}`, expectedresult: "[{1 dev1 true} {2 dev2 true}]",
+		},
+		{
+			name: "partial failure",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) ([]foreman.BulkHostResult, error) {
+				return ci.BulkBuild(ctx, "name ~ dev", true)
+			},
+			status: http.StatusUnprocessableEntity, response: `{"error":{"message":"Failed to build dev2","failed_host_ids":[2]}}`,
+			expectedrequest: `PUT /api/hosts/bulk/build {"included":{"search":"name ~ dev"},"reboot":true}`, expectedresult: "[{1 dev1 true} {2 dev2 false}]",
+		},
+		{
+			name: "server error",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) ([]foreman.BulkHostResult, error) {
+				return ci.BulkPower(ctx, "name ~ dev", "cycle")
+			},
+			status: http.StatusInternalServerError, response: `{"error":{"message":"boom"}}`,
+			expectedrequest: `PUT /api/hosts/bulk/change_power_state {"included":{"search":"name ~ dev"},"power":"cycle"}`, expectedresult: "[]", expectederr: true,
+		},
+	}
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, bulkTimeout*time.Second)
+	defer cancel()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var request string
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if req.Method == http.MethodGet {
+					check(rw.Write([]byte(bulkHosts)))
+					return
+				}
+				data, _ := ioutil.ReadAll(req.Body)
+				request = req.Method + " " + req.URL.Path + " " + string(data)
+				rw.WriteHeader(tc.status)
+				check(rw.Write([]byte(tc.response)))
+			}))
+			defer server.Close()
+
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+			results, err := tc.call(&api, ctx)
+			if tc.expectederr != (err != nil) {
+				t.Fatalf("Test %v error expected %v, got `%v`", tc.name, tc.expectederr, err)
+			}
+			if tc.expectedrequest != request || tc.expectedresult != fmt.Sprint(results) {
+				t.Errorf("Test %v result should be %v %v, got  `%v %v`", tc.name, tc.expectedrequest, tc.expectedresult, request, results)
+			}
+			log.Printf("Response: %v", results)
+		})
+	}
+}
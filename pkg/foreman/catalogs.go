@@ -0,0 +1,175 @@
+package foreman
+
+import (
+	"context"
+	"strconv"
+)
+
+const (
+	operatingsystemsapi = "api/operatingsystems"
+	architecturesapi    = "api/architectures"
+	mediaapi            = "api/media"
+	ptablesapi          = "api/ptables"
+)
+
+// OperatingSystem represents a foreman operating system
+type OperatingSystem struct {
+	ID              int    `json:"id,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Title           string `json:"title,omitempty"`
+	Major           string `json:"major,omitempty"`
+	Minor           string `json:"minor,omitempty"`
+	Family          string `json:"family,omitempty"`
+	ReleaseName     string `json:"release_name,omitempty"`
+	Description     string `json:"description,omitempty"`
+	PasswordHash    string `json:"password_hash,omitempty"`
+	ArchitectureIDs []int  `json:"architecture_ids,omitempty"`
+	MediumIDs       []int  `json:"medium_ids,omitempty"`
+	PtableIDs       []int  `json:"ptable_ids,omitempty"`
+}
+
+// Architecture represents a foreman architecture
+type Architecture struct {
+	ID                 int    `json:"id,omitempty"`
+	Name               string `json:"name,omitempty"`
+	OperatingSystemIDs []int  `json:"operatingsystem_ids,omitempty"`
+}
+
+// Medium represents a foreman installation medium
+type Medium struct {
+	ID                 int    `json:"id,omitempty"`
+	Name               string `json:"name,omitempty"`
+	Path               string `json:"path,omitempty"`
+	OSFamily           string `json:"os_family,omitempty"`
+	OperatingSystemIDs []int  `json:"operatingsystem_ids,omitempty"`
+}
+
+// PartitionTable represents a foreman partition table
+type PartitionTable struct {
+	ID                 int    `json:"id,omitempty"`
+	Name               string `json:"name,omitempty"`
+	Layout             string `json:"layout,omitempty"`
+	OSFamily           string `json:"os_family,omitempty"`
+	Locked             *bool  `json:"locked,omitempty"`
+	OperatingSystemIDs []int  `json:"operatingsystem_ids,omitempty"`
+}
+
+// OperatingSystemsResource returns the typed resource for operating systems
+func (ci *ConnectionInfo) OperatingSystemsResource() *Resource[OperatingSystem] {
+	return NewResource[OperatingSystem](ci, "operatingsystem", operatingsystemsapi)
+}
+
+// ArchitecturesResource returns the typed resource for architectures
+func (ci *ConnectionInfo) ArchitecturesResource() *Resource[Architecture] {
+	return NewResource[Architecture](ci, "architecture", architecturesapi)
+}
+
+// MediaResource returns the typed resource for installation media
+func (ci *ConnectionInfo) MediaResource() *Resource[Medium] {
+	return NewResource[Medium](ci, "medium", mediaapi)
+}
+
+// PartitionTablesResource returns the typed resource for partition tables
+func (ci *ConnectionInfo) PartitionTablesResource() *Resource[PartitionTable] {
+	return NewResource[PartitionTable](ci, "ptable", ptablesapi)
+}
+
+// ListOperatingSystems returns the operating systems matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListOperatingSystems(ctx context.Context, search string) ([]OperatingSystem, error) {
+	return ci.OperatingSystemsResource().List(ctx, search)
+}
+
+// GetOperatingSystem returns the operating system with the id provided
+func (ci *ConnectionInfo) GetOperatingSystem(ctx context.Context, id int) (*OperatingSystem, error) {
+	return ci.OperatingSystemsResource().Get(ctx, strconv.Itoa(id))
+}
+
+// CreateOperatingSystem creates a new operating system
+func (ci *ConnectionInfo) CreateOperatingSystem(ctx context.Context, os OperatingSystem) (*OperatingSystem, error) {
+	return ci.OperatingSystemsResource().Create(ctx, os)
+}
+
+// UpdateOperatingSystem updates the operating system with the id provided
+func (ci *ConnectionInfo) UpdateOperatingSystem(ctx context.Context, id int, os OperatingSystem) (*OperatingSystem, error) {
+	return ci.OperatingSystemsResource().Update(ctx, strconv.Itoa(id), os)
+}
+
+// ResolveOperatingSystem returns the id of the operating system with the name or title provided
+func (ci *ConnectionInfo) ResolveOperatingSystem(ctx context.Context, name string) (int, error) {
+	return ci.OperatingSystemsResource().Resolve(ctx, name)
+}
+
+// ListArchitectures returns the architectures matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListArchitectures(ctx context.Context, search string) ([]Architecture, error) {
+	return ci.ArchitecturesResource().List(ctx, search)
+}
+
+// GetArchitecture returns the architecture with the id provided
+func (ci *ConnectionInfo) GetArchitecture(ctx context.Context, id int) (*Architecture, error) {
+	return ci.ArchitecturesResource().Get(ctx, strconv.Itoa(id))
+}
+
+// CreateArchitecture creates a new architecture
+func (ci *ConnectionInfo) CreateArchitecture(ctx context.Context, arch Architecture) (*Architecture, error) {
+	return ci.ArchitecturesResource().Create(ctx, arch)
+}
+
+// UpdateArchitecture updates the architecture with the id provided
+func (ci *ConnectionInfo) UpdateArchitecture(ctx context.Context, id int, arch Architecture) (*Architecture, error) {
+	return ci.ArchitecturesResource().Update(ctx, strconv.Itoa(id), arch)
+}
+
+// ResolveArchitecture returns the id of the architecture with the name provided
+func (ci *ConnectionInfo) ResolveArchitecture(ctx context.Context, name string) (int, error) {
+	return ci.ArchitecturesResource().Resolve(ctx, name)
+}
+
+// ListMedia returns the installation media matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListMedia(ctx context.Context, search string) ([]Medium, error) {
+	return ci.MediaResource().List(ctx, search)
+}
+
+// GetMedium returns the installation medium with the id provided
+func (ci *ConnectionInfo) GetMedium(ctx context.Context, id int) (*Medium, error) {
+	return ci.MediaResource().Get(ctx, strconv.Itoa(id))
+}
+
+// CreateMedium creates a new installation medium
+func (ci *ConnectionInfo) CreateMedium(ctx context.Context, medium Medium) (*Medium, error) {
+	return ci.MediaResource().Create(ctx, medium)
+}
+
+// UpdateMedium updates the installation medium with the id provided
+func (ci *ConnectionInfo) UpdateMedium(ctx context.Context, id int, medium Medium) (*Medium, error) {
+	return ci.MediaResource().Update(ctx, strconv.Itoa(id), medium)
+}
+
+// ResolveMedium returns the id of the installation medium with the name provided
+func (ci *ConnectionInfo) ResolveMedium(ctx context.Context, name string) (int, error) {
+	return ci.MediaResource().Resolve(ctx, name)
+}
+
+// ListPartitionTables returns the partition tables matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListPartitionTables(ctx context.Context, search string) ([]PartitionTable, error) {
+	return ci.PartitionTablesResource().List(ctx, search)
+}
+
+// GetPartitionTable returns the partition table with the id provided
+func (ci *ConnectionInfo) GetPartitionTable(ctx context.Context, id int) (*PartitionTable, error) {
+	return ci.PartitionTablesResource().Get(ctx, strconv.Itoa(id))
+}
+
+// CreatePartitionTable creates a new partition table
+func (ci *ConnectionInfo) CreatePartitionTable(ctx context.Context, ptable PartitionTable) (*PartitionTable, error) {
+	return ci.PartitionTablesResource().Create(ctx, ptable)
+}
+
+// UpdatePartitionTable updates the partition table with the id provided
+func (ci *ConnectionInfo) UpdatePartitionTable(ctx context.Context, id int, ptable PartitionTable) (*PartitionTable, error) {
+	return ci.PartitionTablesResource().Update(ctx, strconv.Itoa(id), ptable)
+}
+
+// ResolvePartitionTable returns the id of the partition table with the name provided
+func (ci *ConnectionInfo) ResolvePartitionTable(ctx context.Context, name string) (int, error) {
+	return ci.PartitionTablesResource().Resolve(ctx, name)
+}
@@ -0,0 +1,113 @@
+package foreman_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	catalogsTimeout = 180
+)
+
+func ExampleConnectionInfo_ListOperatingSystems() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(`{"total":1,"subtotal":1,"page":1,"per_page":100,"results":[{"id":2,"name":"CentOS","title":"CentOS 7.9","major":"7","minor":"9"}]}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, catalogsTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	oses, err := api.ListOperatingSystems(ctx, "")
+
+	fmt.Printf("%d %s %v", oses[0].ID, oses[0].Title, err)
+
+	// Output: 2 CentOS 7.9 <nil>
+}
+
+func TestResolveCatalogs(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/operatingsystems":
+			if req.URL.Query().Get("search") != `name = "CentOS 7.9" or title = "CentOS 7.9"` {
+				check(rw.Write([]byte(`{"total":0,"subtotal":0,"page":1,"per_page":100,"results":[]}`)))
+				return
+			}
+			check(rw.Write([]byte(`{"total":2,"subtotal":1,"page":1,"per_page":100,"results":[{"id":2,"name":"CentOS","title":"CentOS 7.9"}]}`)))
+		case "/api/architectures":
+			check(rw.Write([]byte(`{"total":2,"subtotal":1,"page":1,"per_page":100,"results":[{"id":1,"name":"x86_64"}]}`)))
+		case "/api/media":
+			check(rw.Write([]byte(`{"total":2,"subtotal":2,"page":1,"per_page":100,"results":[{"id":3,"name":"mirror"},{"id":4,"name":"mirror"}]}`)))
+		default:
+			check(rw.Write([]byte(`{"total":0,"subtotal":0,"page":1,"per_page":100,"results":[]}`)))
+		}
+	}))
+	defer server.Close()
+
+	tt := []struct {
+		name           string
+		resolve        func(*foreman.ConnectionInfo, context.Context, string) (int, error)
+		value          string
+		expectedresult int
+		expectederr    bool
+	}{
+		{name: "numeric id", resolve: (*foreman.ConnectionInfo).ResolveOperatingSystem, value: "7", expectedresult: 7},
+		{name: "title found", resolve: (*foreman.ConnectionInfo).ResolveOperatingSystem, value: "CentOS 7.9", expectedresult: 2},
+		{name: "name found", resolve: (*foreman.ConnectionInfo).ResolveArchitecture, value: "x86_64", expectedresult: 1},
+		{name: "name ambiguous", resolve: (*foreman.ConnectionInfo).ResolveMedium, value: "mirror", expectederr: true},
+		{name: "name not found", resolve: (*foreman.ConnectionInfo).ResolvePartitionTable, value: "missing", expectederr: true},
+	}
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, catalogsTimeout*time.Second)
+	defer cancel()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+			id, err := tc.resolve(&api, ctx, tc.value)
+			if tc.expectederr != (err != nil) {
+				t.Fatalf("Test %v error expected %v, got `%v`", tc.name, tc.expectederr, err)
+			}
+			if tc.expectedresult != id {
+				t.Errorf("Test %v result should be %v, got `%v`", tc.name, tc.expectedresult, id)
+			}
+			log.Printf("Response: %d %v", id, err)
+		})
+	}
+}
+
+func TestCatalogAPIError(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusUnprocessableEntity)
+		check(rw.Write([]byte(`{"error":{"id":null,"errors":{"name":["has already been taken"]},"full_messages":["Name has already been taken"]}}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, catalogsTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	_, err := api.CreateArchitecture(ctx, foreman.Architecture{Name: "x86_64"})
+
+	apiErr, ok := err.(*foreman.APIError)
+	if !ok {
+		t.Fatalf("error should be an APIError, got `%v`", err)
+	}
+	if apiErr.StatusCode != http.StatusUnprocessableEntity || apiErr.Message != "Name has already been taken" {
+		t.Errorf("unexpected api error `%v`", apiErr)
+	}
+}
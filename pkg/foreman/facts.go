@@ -0,0 +1,85 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	factvaluesapi = "api/fact_values"
+)
+
+// FactValues contains fact values keyed by host name and then by fact name
+type FactValues map[string]map[string]string
+
+// GetHostFacts returns the facts reported for the host
+func (ci *ConnectionInfo) GetHostFacts(ctx context.Context) (map[string]string, error) {
+
+	values, err := ci.listFacts(ctx, "", hostsapi, ci.Hostname, "facts")
+	if err != nil {
+		return nil, err
+	}
+
+	facts := map[string]string{}
+	for _, hostFacts := range values {
+		for name, value := range hostFacts {
+			facts[name] = value
+		}
+	}
+
+	return facts, nil
+}
+
+// ListFactValues returns the fact values across all hosts matching the search, e.g. fact = os::family and value = RedHat
+func (ci *ConnectionInfo) ListFactValues(ctx context.Context, search string) (FactValues, error) {
+	return ci.listFacts(ctx, search, factvaluesapi)
+}
+
+// SearchHostsByFact returns the names of the hosts that reported the fact with the value provided
+func (ci *ConnectionInfo) SearchHostsByFact(ctx context.Context, fact string, value string) ([]string, error) {
+
+	hosts, err := ci.HostsResource().List(ctx, fmt.Sprintf("facts.%s = %q", fact, value))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		names = append(names, h.Name)
+	}
+
+	return names, nil
+}
+
+// listFacts pages through a facts endpoint, whose results are a map rather than a list
+func (ci *ConnectionInfo) listFacts(ctx context.Context, search string, elem ...string) (FactValues, error) {
+
+	values := FactValues{}
+
+	err := ci.pages(ctx, search, func(page json.RawMessage) (int, error) {
+		var pageValues map[string]map[string]string
+		if len(page) > 0 {
+			if err := json.Unmarshal(page, &pageValues); err != nil {
+				return 0, err
+			}
+		}
+
+		count := 0
+		for host, facts := range pageValues {
+			if values[host] == nil {
+				values[host] = map[string]string{}
+			}
+			for name, value := range facts {
+				values[host][name] = value
+				count++
+			}
+		}
+		return count, nil
+	}, elem...)
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
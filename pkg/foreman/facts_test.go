@@ -0,0 +1,98 @@
+package foreman_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	factsTimeout = 180
+)
+
+func ExampleConnectionInfo_GetHostFacts() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(`{"total":2,"subtotal":2,"page":1,"per_page":100,"results":{"test.example.com":{"os::family":"RedHat","memorysize_mb":"2048"}}}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, factsTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client(), Hostname: "test.example.com"}
+	facts, err := api.GetHostFacts(ctx)
+
+	fmt.Printf("%s %s %v", facts["os::family"], facts["memorysize_mb"], err)
+
+	// Output: RedHat 2048 <nil>
+}
+
+func TestListFactValuesPages(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Query().Get("page") {
+		case "1":
+			check(rw.Write([]byte(`{"total":9,"subtotal":2,"page":1,"per_page":1,"results":{"host1":{"os::family":"RedHat"}}}`)))
+		default:
+			check(rw.Write([]byte(`{"total":9,"subtotal":2,"page":2,"per_page":1,"results":{"host2":{"os::family":"RedHat"}}}`)))
+		}
+	}))
+	defer server.Close()
+
+	tt := []struct {
+		name           string
+		search         string
+		expectedresult int
+	}{
+		{name: "two pages", search: "fact = os::family", expectedresult: 2},
+	}
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, factsTimeout*time.Second)
+	defer cancel()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+			values, err := api.ListFactValues(ctx, tc.search)
+			if err != nil {
+				t.Fatalf("Could not read response %v correctly", err)
+			}
+			if tc.expectedresult != len(values) {
+				t.Errorf("Test %v result should be %v, got  `%v`", tc.name, tc.expectedresult, values)
+			}
+			log.Printf("Response: %v", values)
+		})
+	}
+}
+
+func TestSearchHostsByFact(t *testing.T) {
+
+	var search string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		search = req.URL.Query().Get("search")
+		check(rw.Write([]byte(`{"total":3,"subtotal":1,"page":1,"per_page":100,"results":[{"id":1,"name":"host1"}]}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, factsTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	hosts, err := api.SearchHostsByFact(ctx, "os::family", "RedHat")
+	if err != nil {
+		t.Fatalf("Could not read response %v correctly", err)
+	}
+	if search != `facts.os::family = "RedHat"` || len(hosts) != 1 || hosts[0] != "host1" {
+		t.Errorf("unexpected search `%v` or hosts `%v`", search, hosts)
+	}
+}
@@ -0,0 +1,442 @@
+// Code generated by foreman-gen from apidoc/v2.json. DO NOT EDIT.
+
+package foreman
+
+import "context"
+
+const (
+	authsourceexternalsapi     = "api/auth_source_externals"
+	authsourceinternalsapi     = "api/auth_source_internals"
+	authsourceldapsapi         = "api/auth_source_ldaps"
+	configgroupsapi            = "api/config_groups"
+	httpproxiesapi             = "api/http_proxies"
+	mailnotificationsapi       = "api/mail_notifications"
+	recurringlogicsapi         = "foreman_tasks/api/recurring_logics"
+	remoteexecutionfeaturesapi = "api/remote_execution_features"
+	reporttemplatesapi         = "api/report_templates"
+	apitemplatesapi            = "api/templates"
+	webhooktemplatesapi        = "api/webhook_templates"
+	webhooksapi                = "api/webhooks"
+)
+
+// AuthSourceExternal is generated from the auth_source_externals resource of the foreman api
+type AuthSourceExternal struct {
+	ID              int     `json:"id,omitempty"`
+	Name            *string `json:"name,omitempty"`
+	LocationIDs     []int   `json:"location_ids,omitempty"`
+	OrganizationIDs []int   `json:"organization_ids,omitempty"`
+}
+
+// AuthSourceExternalsResource returns the typed resource for auth source externals
+func (ci *ConnectionInfo) AuthSourceExternalsResource() *Resource[AuthSourceExternal] {
+	return NewResource[AuthSourceExternal](ci, "auth_source_external", authsourceexternalsapi)
+}
+
+// AuthSourceInternal is generated from the auth_source_internals resource of the foreman api
+type AuthSourceInternal struct {
+	ID   int     `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+// AuthSourceInternalsResource returns the typed resource for auth source internals
+func (ci *ConnectionInfo) AuthSourceInternalsResource() *Resource[AuthSourceInternal] {
+	return NewResource[AuthSourceInternal](ci, "auth_source_internal", authsourceinternalsapi)
+}
+
+// AuthSourceLDAP is generated from the auth_source_ldaps resource of the foreman api
+type AuthSourceLDAP struct {
+	ID               int     `json:"id,omitempty"`
+	Name             string  `json:"name,omitempty"`
+	Host             string  `json:"host,omitempty"`
+	Port             *int    `json:"port,omitempty"`
+	Account          *string `json:"account,omitempty"`
+	BaseDN           *string `json:"base_dn,omitempty"`
+	AccountPassword  *string `json:"account_password,omitempty"`
+	AttrLogin        *string `json:"attr_login,omitempty"`
+	AttrFirstname    *string `json:"attr_firstname,omitempty"`
+	AttrLastname     *string `json:"attr_lastname,omitempty"`
+	AttrMail         *string `json:"attr_mail,omitempty"`
+	AttrPhoto        *string `json:"attr_photo,omitempty"`
+	OntheflyRegister *bool   `json:"onthefly_register,omitempty"`
+	UsergroupSync    *bool   `json:"usergroup_sync,omitempty"`
+	TLS              *bool   `json:"tls,omitempty"`
+	GroupsBase       *string `json:"groups_base,omitempty"`
+	UseNetgroups     *bool   `json:"use_netgroups,omitempty"`
+	ServerType       *string `json:"server_type,omitempty"`
+	LDAPFilter       *string `json:"ldap_filter,omitempty"`
+	LocationIDs      []int   `json:"location_ids,omitempty"`
+	OrganizationIDs  []int   `json:"organization_ids,omitempty"`
+}
+
+// AuthSourceLDAPsResource returns the typed resource for auth source ldaps
+func (ci *ConnectionInfo) AuthSourceLDAPsResource() *Resource[AuthSourceLDAP] {
+	return NewResource[AuthSourceLDAP](ci, "auth_source_ldap", authsourceldapsapi)
+}
+
+// AuthSourceLDAPTest calls PUT /api/auth_source_ldaps/:id/test, test LDAP connection
+func (ci *ConnectionInfo) AuthSourceLDAPTest(ctx context.Context, id string) ([]byte, error) {
+	return ci.action(ctx, "PUT", nil, authsourceldapsapi, id, "test")
+}
+
+// ConfigGroup is generated from the config_groups resource of the foreman api
+type ConfigGroup struct {
+	ID             int    `json:"id,omitempty"`
+	Name           string `json:"name,omitempty"`
+	PuppetclassIDs []int  `json:"puppetclass_ids,omitempty"`
+}
+
+// ConfigGroupsResource returns the typed resource for config groups
+func (ci *ConnectionInfo) ConfigGroupsResource() *Resource[ConfigGroup] {
+	return NewResource[ConfigGroup](ci, "config_group", configgroupsapi)
+}
+
+// HTTPProxy is generated from the http_proxies resource of the foreman api
+type HTTPProxy struct {
+	ID                      int     `json:"id,omitempty"`
+	Name                    string  `json:"name,omitempty"`
+	URL                     string  `json:"url,omitempty"`
+	Username                *string `json:"username,omitempty"`
+	Password                *string `json:"password,omitempty"`
+	ContentDefaultHTTPProxy *bool   `json:"content_default_http_proxy,omitempty"`
+	LocationIDs             []int   `json:"location_ids,omitempty"`
+	OrganizationIDs         []int   `json:"organization_ids,omitempty"`
+}
+
+// HTTPProxiesResource returns the typed resource for http proxies
+func (ci *ConnectionInfo) HTTPProxiesResource() *Resource[HTTPProxy] {
+	return NewResource[HTTPProxy](ci, "http_proxy", httpproxiesapi)
+}
+
+// Image is generated from the images resource of the foreman api
+type Image struct {
+	ID                int     `json:"id,omitempty"`
+	Name              string  `json:"name,omitempty"`
+	Username          string  `json:"username,omitempty"`
+	UUID              string  `json:"uuid,omitempty"`
+	Password          *string `json:"password,omitempty"`
+	ComputeResourceID *int    `json:"compute_resource_id,omitempty"`
+	ArchitectureID    int     `json:"architecture_id,omitempty"`
+	OperatingsystemID int     `json:"operatingsystem_id,omitempty"`
+	UserData          *bool   `json:"user_data,omitempty"`
+}
+
+// ImagesResource returns the typed resource for images
+func (ci *ConnectionInfo) ImagesResource(computeResourceID string) *Resource[Image] {
+	return NewResource[Image](ci, "image", computeresourcesapi, computeResourceID, "images")
+}
+
+// Interface is generated from the interfaces resource of the foreman api
+type Interface struct {
+	ID                int                    `json:"id,omitempty"`
+	MAC               *string                `json:"mac,omitempty"`
+	IP                *string                `json:"ip,omitempty"`
+	Ip6               *string                `json:"ip6,omitempty"`
+	Type              *string                `json:"type,omitempty"`
+	Name              *string                `json:"name,omitempty"`
+	SubnetID          *int                   `json:"subnet_id,omitempty"`
+	Subnet6ID         *int                   `json:"subnet6_id,omitempty"`
+	DomainID          *int                   `json:"domain_id,omitempty"`
+	Identifier        *string                `json:"identifier,omitempty"`
+	Managed           *bool                  `json:"managed,omitempty"`
+	Primary           *bool                  `json:"primary,omitempty"`
+	Provision         *bool                  `json:"provision,omitempty"`
+	Username          *string                `json:"username,omitempty"`
+	Password          *string                `json:"password,omitempty"`
+	Provider          *string                `json:"provider,omitempty"`
+	Virtual           *bool                  `json:"virtual,omitempty"`
+	Tag               *string                `json:"tag,omitempty"`
+	Mtu               *int                   `json:"mtu,omitempty"`
+	AttachedTo        *string                `json:"attached_to,omitempty"`
+	Mode              *string                `json:"mode,omitempty"`
+	AttachedDevices   []string               `json:"attached_devices,omitempty"`
+	BondOptions       *string                `json:"bond_options,omitempty"`
+	ComputeAttributes map[string]interface{} `json:"compute_attributes,omitempty"`
+}
+
+// InterfacesResource returns the typed resource for interfaces
+func (ci *ConnectionInfo) InterfacesResource(hostID string) *Resource[Interface] {
+	return NewResource[Interface](ci, "interface", hostsapi, hostID, "interfaces")
+}
+
+// MailNotification is generated from the mail_notifications resource of the foreman api
+type MailNotification struct {
+	ID   int     `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+// MailNotificationsResource returns the typed resource for mail notifications
+func (ci *ConnectionInfo) MailNotificationsResource() *Resource[MailNotification] {
+	return NewResource[MailNotification](ci, "mail_notification", mailnotificationsapi)
+}
+
+// OsDefaultTemplate is generated from the os_default_templates resource of the foreman api
+type OsDefaultTemplate struct {
+	ID                     int     `json:"id,omitempty"`
+	Name                   *string `json:"name,omitempty"`
+	TemplateKindID         *int    `json:"template_kind_id,omitempty"`
+	ProvisioningTemplateID *int    `json:"provisioning_template_id,omitempty"`
+}
+
+// OsDefaultTemplatesResource returns the typed resource for os default templates
+func (ci *ConnectionInfo) OsDefaultTemplatesResource(operatingsystemID string) *Resource[OsDefaultTemplate] {
+	return NewResource[OsDefaultTemplate](ci, "os_default_template", operatingsystemsapi, operatingsystemID, "os_default_templates")
+}
+
+// RecurringLogic is generated from the recurring_logics resource of the foreman api
+type RecurringLogic struct {
+	ID      int     `json:"id,omitempty"`
+	Name    *string `json:"name,omitempty"`
+	Enabled *bool   `json:"enabled,omitempty"`
+}
+
+// RecurringLogicsResource returns the typed resource for recurring logics
+func (ci *ConnectionInfo) RecurringLogicsResource() *Resource[RecurringLogic] {
+	return NewResource[RecurringLogic](ci, "recurring_logic", recurringlogicsapi)
+}
+
+// RecurringLogicCancel calls POST /foreman_tasks/api/recurring_logics/:id/cancel, cancel recurring logic
+func (ci *ConnectionInfo) RecurringLogicCancel(ctx context.Context, id string) ([]byte, error) {
+	return ci.action(ctx, "POST", nil, recurringlogicsapi, id, "cancel")
+}
+
+// RecurringLogicsBulkDestroyParams are the parameters of RecurringLogicsBulkDestroy
+type RecurringLogicsBulkDestroyParams struct {
+	Search string `json:"search,omitempty"`
+}
+
+// RecurringLogicsBulkDestroy calls POST /foreman_tasks/api/recurring_logics/bulk_destroy, delete recurring logics by search query
+func (ci *ConnectionInfo) RecurringLogicsBulkDestroy(ctx context.Context, params RecurringLogicsBulkDestroyParams) ([]byte, error) {
+	return ci.action(ctx, "POST", params, recurringlogicsapi, "bulk_destroy")
+}
+
+// RemoteExecutionFeature is generated from the remote_execution_features resource of the foreman api
+type RemoteExecutionFeature struct {
+	ID            int     `json:"id,omitempty"`
+	Name          *string `json:"name,omitempty"`
+	JobTemplateID *int    `json:"job_template_id,omitempty"`
+}
+
+// RemoteExecutionFeaturesResource returns the typed resource for remote execution features
+func (ci *ConnectionInfo) RemoteExecutionFeaturesResource() *Resource[RemoteExecutionFeature] {
+	return NewResource[RemoteExecutionFeature](ci, "remote_execution_feature", remoteexecutionfeaturesapi)
+}
+
+// ReportTemplate is generated from the report_templates resource of the foreman api
+type ReportTemplate struct {
+	ID              int     `json:"id,omitempty"`
+	Name            string  `json:"name,omitempty"`
+	Description     *string `json:"description,omitempty"`
+	Template        string  `json:"template,omitempty"`
+	Snippet         *bool   `json:"snippet,omitempty"`
+	AuditComment    *string `json:"audit_comment,omitempty"`
+	Locked          *bool   `json:"locked,omitempty"`
+	Default         *bool   `json:"default,omitempty"`
+	LocationIDs     []int   `json:"location_ids,omitempty"`
+	OrganizationIDs []int   `json:"organization_ids,omitempty"`
+}
+
+// ReportTemplatesResource returns the typed resource for report templates
+func (ci *ConnectionInfo) ReportTemplatesResource() *Resource[ReportTemplate] {
+	return NewResource[ReportTemplate](ci, "report_template", reporttemplatesapi)
+}
+
+// ReportTemplateCloneParams are the parameters of ReportTemplateClone
+type ReportTemplateCloneParams struct {
+	ReportTemplate map[string]interface{} `json:"report_template,omitempty"`
+}
+
+// ReportTemplateClone calls POST /api/report_templates/:id/clone, clone a template
+func (ci *ConnectionInfo) ReportTemplateClone(ctx context.Context, id string, params ReportTemplateCloneParams) ([]byte, error) {
+	return ci.action(ctx, "POST", params, reporttemplatesapi, id, "clone")
+}
+
+// ReportTemplateExport calls GET /api/report_templates/:id/export, export a report template to ERB
+func (ci *ConnectionInfo) ReportTemplateExport(ctx context.Context, id string) ([]byte, error) {
+	return ci.action(ctx, "GET", nil, reporttemplatesapi, id, "export")
+}
+
+// ReportTemplatesImportParams are the parameters of ReportTemplatesImport
+type ReportTemplatesImportParams struct {
+	ReportTemplate map[string]interface{} `json:"report_template,omitempty"`
+}
+
+// ReportTemplatesImport calls POST /api/report_templates/import, import a report template
+func (ci *ConnectionInfo) ReportTemplatesImport(ctx context.Context, params ReportTemplatesImportParams) ([]byte, error) {
+	return ci.action(ctx, "POST", params, reporttemplatesapi, "import")
+}
+
+// ReportTemplateGenerateParams are the parameters of ReportTemplateGenerate
+type ReportTemplateGenerateParams struct {
+	InputValues  map[string]interface{} `json:"input_values,omitempty"`
+	Gzip         *bool                  `json:"gzip,omitempty"`
+	ReportFormat *string                `json:"report_format,omitempty"`
+}
+
+// ReportTemplateGenerate calls POST /api/report_templates/:id/generate, generate report
+func (ci *ConnectionInfo) ReportTemplateGenerate(ctx context.Context, id string, params ReportTemplateGenerateParams) ([]byte, error) {
+	return ci.action(ctx, "POST", params, reporttemplatesapi, id, "generate")
+}
+
+// ReportTemplateScheduleReportParams are the parameters of ReportTemplateScheduleReport
+type ReportTemplateScheduleReportParams struct {
+	InputValues  map[string]interface{} `json:"input_values,omitempty"`
+	Gzip         *bool                  `json:"gzip,omitempty"`
+	MailTo       *string                `json:"mail_to,omitempty"`
+	GenerateAt   *string                `json:"generate_at,omitempty"`
+	ReportFormat *string                `json:"report_format,omitempty"`
+}
+
+// ReportTemplateScheduleReport calls POST /api/report_templates/:id/schedule_report, schedule generating of a report
+func (ci *ConnectionInfo) ReportTemplateScheduleReport(ctx context.Context, id string, params ReportTemplateScheduleReportParams) ([]byte, error) {
+	return ci.action(ctx, "POST", params, reporttemplatesapi, id, "schedule_report")
+}
+
+// ReportTemplateReportData calls GET /api/report_templates/:id/report_data/:job_id, downloads a generated report
+func (ci *ConnectionInfo) ReportTemplateReportData(ctx context.Context, id, jobID string) ([]byte, error) {
+	return ci.action(ctx, "GET", nil, reporttemplatesapi, id, "report_data", jobID)
+}
+
+// SSHKey is generated from the ssh_keys resource of the foreman api
+type SSHKey struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Key  string `json:"key,omitempty"`
+}
+
+// SSHKeysResource returns the typed resource for ssh keys
+func (ci *ConnectionInfo) SSHKeysResource(userID string) *Resource[SSHKey] {
+	return NewResource[SSHKey](ci, "ssh_key", usersapi, userID, "ssh_keys")
+}
+
+// TablePreferences is generated from the table_preferences resource of the foreman api
+type TablePreferences struct {
+	ID      int      `json:"id,omitempty"`
+	Name    string   `json:"name,omitempty"`
+	Columns []string `json:"columns,omitempty"`
+}
+
+// TablePreferencesResource returns the typed resource for table preferences
+func (ci *ConnectionInfo) TablePreferencesResource(userID string) *Resource[TablePreferences] {
+	return NewResource[TablePreferences](ci, "table_preferences", usersapi, userID, "table_preferences")
+}
+
+// TemplateCombination is generated from the template_combinations resource of the foreman api
+type TemplateCombination struct {
+	ID            int     `json:"id,omitempty"`
+	Name          *string `json:"name,omitempty"`
+	EnvironmentID *int    `json:"environment_id,omitempty"`
+	HostgroupID   *int    `json:"hostgroup_id,omitempty"`
+}
+
+// TemplateCombinationsResource returns the typed resource for template combinations
+func (ci *ConnectionInfo) TemplateCombinationsResource(provisioningTemplateID string) *Resource[TemplateCombination] {
+	return NewResource[TemplateCombination](ci, "template_combination", templatesapi, provisioningTemplateID, "template_combinations")
+}
+
+// TemplateInput is generated from the template_inputs resource of the foreman api
+type TemplateInput struct {
+	ID                  int      `json:"id,omitempty"`
+	Name                string   `json:"name,omitempty"`
+	Description         *string  `json:"description,omitempty"`
+	Required            *bool    `json:"required,omitempty"`
+	Advanced            *bool    `json:"advanced,omitempty"`
+	InputType           string   `json:"input_type,omitempty"`
+	FactName            *string  `json:"fact_name,omitempty"`
+	VariableName        *string  `json:"variable_name,omitempty"`
+	PuppetParameterName *string  `json:"puppet_parameter_name,omitempty"`
+	PuppetClassName     *string  `json:"puppet_class_name,omitempty"`
+	Options             []string `json:"options,omitempty"`
+	Default             *string  `json:"default,omitempty"`
+	HiddenValue         *bool    `json:"hidden_value,omitempty"`
+	ValueType           *string  `json:"value_type,omitempty"`
+	ResourceType        *string  `json:"resource_type,omitempty"`
+}
+
+// TemplateInputsResource returns the typed resource for template inputs
+func (ci *ConnectionInfo) TemplateInputsResource(templateID string) *Resource[TemplateInput] {
+	return NewResource[TemplateInput](ci, "template_input", apitemplatesapi, templateID, "template_inputs")
+}
+
+// WebhookTemplate is generated from the webhook_templates resource of the foreman api
+type WebhookTemplate struct {
+	ID              int     `json:"id,omitempty"`
+	Name            string  `json:"name,omitempty"`
+	Description     *string `json:"description,omitempty"`
+	Template        string  `json:"template,omitempty"`
+	Snippet         *bool   `json:"snippet,omitempty"`
+	AuditComment    *string `json:"audit_comment,omitempty"`
+	Locked          *bool   `json:"locked,omitempty"`
+	Default         *bool   `json:"default,omitempty"`
+	LocationIDs     []int   `json:"location_ids,omitempty"`
+	OrganizationIDs []int   `json:"organization_ids,omitempty"`
+}
+
+// WebhookTemplatesResource returns the typed resource for webhook templates
+func (ci *ConnectionInfo) WebhookTemplatesResource() *Resource[WebhookTemplate] {
+	return NewResource[WebhookTemplate](ci, "webhook_template", webhooktemplatesapi)
+}
+
+// WebhookTemplateCloneParams are the parameters of WebhookTemplateClone
+type WebhookTemplateCloneParams struct {
+	WebhookTemplate map[string]interface{} `json:"webhook_template,omitempty"`
+}
+
+// WebhookTemplateClone calls POST /api/webhook_templates/:id/clone, clone a template
+func (ci *ConnectionInfo) WebhookTemplateClone(ctx context.Context, id string, params WebhookTemplateCloneParams) ([]byte, error) {
+	return ci.action(ctx, "POST", params, webhooktemplatesapi, id, "clone")
+}
+
+// WebhookTemplateExport calls GET /api/webhook_templates/:id/export, export a webhook template to ERB
+func (ci *ConnectionInfo) WebhookTemplateExport(ctx context.Context, id string) ([]byte, error) {
+	return ci.action(ctx, "GET", nil, webhooktemplatesapi, id, "export")
+}
+
+// WebhookTemplatesImportParams are the parameters of WebhookTemplatesImport
+type WebhookTemplatesImportParams struct {
+	WebhookTemplate map[string]interface{} `json:"webhook_template,omitempty"`
+}
+
+// WebhookTemplatesImport calls POST /api/webhook_templates/import, import a webhook template
+func (ci *ConnectionInfo) WebhookTemplatesImport(ctx context.Context, params WebhookTemplatesImportParams) ([]byte, error) {
+	return ci.action(ctx, "POST", params, webhooktemplatesapi, "import")
+}
+
+// Webhook is generated from the webhooks resource of the foreman api
+type Webhook struct {
+	ID                 int     `json:"id,omitempty"`
+	Name               string  `json:"name,omitempty"`
+	TargetURL          string  `json:"target_url,omitempty"`
+	HTTPMethod         *string `json:"http_method,omitempty"`
+	HTTPContentType    *string `json:"http_content_type,omitempty"`
+	Event              string  `json:"event,omitempty"`
+	WebhookTemplateID  *int    `json:"webhook_template_id,omitempty"`
+	Enabled            *bool   `json:"enabled,omitempty"`
+	VerifySSL          *bool   `json:"verify_ssl,omitempty"`
+	SSLCACerts         *string `json:"ssl_ca_certs,omitempty"`
+	User               *string `json:"user,omitempty"`
+	Password           *string `json:"password,omitempty"`
+	HTTPHeaders        *string `json:"http_headers,omitempty"`
+	ProxyAuthorization *bool   `json:"proxy_authorization,omitempty"`
+	LocationIDs        []int   `json:"location_ids,omitempty"`
+	OrganizationIDs    []int   `json:"organization_ids,omitempty"`
+}
+
+// WebhooksResource returns the typed resource for webhooks
+func (ci *ConnectionInfo) WebhooksResource() *Resource[Webhook] {
+	return NewResource[Webhook](ci, "webhook", webhooksapi)
+}
+
+// WebhooksEvents calls GET /api/webhooks/events, list available events for a webhook
+func (ci *ConnectionInfo) WebhooksEvents(ctx context.Context) ([]byte, error) {
+	return ci.action(ctx, "GET", nil, webhooksapi, "events")
+}
+
+// WebhookTestParams are the parameters of WebhookTest
+type WebhookTestParams struct {
+	Payload *string `json:"payload,omitempty"`
+}
+
+// WebhookTest calls POST /api/webhooks/:id/test, test a webhook
+func (ci *ConnectionInfo) WebhookTest(ctx context.Context, id string, params WebhookTestParams) ([]byte, error) {
+	return ci.action(ctx, "POST", params, webhooksapi, id, "test")
+}
@@ -0,0 +1,81 @@
+package foreman
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Metrics is notified of every api call, e.g. to update prometheus counters and histograms.
+// The endpoint has resource ids replaced by :id so it can be used as a label, status is 0 when no response was received
+type Metrics interface {
+	ObserveRequest(method string, endpoint string, status int, duration time.Duration, err error)
+}
+
+// MetricsFunc adapts a function to the Metrics interface
+type MetricsFunc func(method string, endpoint string, status int, duration time.Duration, err error)
+
+// ObserveRequest implements Metrics
+func (f MetricsFunc) ObserveRequest(method string, endpoint string, status int, duration time.Duration, err error) {
+	f(method, endpoint, status, duration, err)
+}
+
+// Tracer starts a span around every api call, e.g. an opentelemetry tracer.
+// Inject adds the trace context of ctx to the request headers so foreman can continue the trace
+type Tracer interface {
+	Start(ctx context.Context, name string, attributes map[string]string) (context.Context, Span)
+	Inject(ctx context.Context, header http.Header)
+}
+
+// Span is ended with the status of the response, 0 when no response was received, and any error
+type Span interface {
+	End(status int, err error)
+}
+
+// nonIDSegments are path elements in the position of an id that name a collection action, no ids follow them
+var nonIDSegments = map[string]bool{"bulk": true, "import": true}
+
+// apiEndpoint returns the api path with resource ids replaced so it has a bounded number of values,
+// e.g. /api/hosts/web01.example.com/power becomes /api/hosts/:id/power
+func apiEndpoint(path string) string {
+
+	i := strings.Index(path, "/api/")
+	if i < 0 {
+		return path
+	}
+
+	// foreman paths alternate between collections and the id of a member
+	segments := strings.Split(strings.Trim(path[i+len("/api/"):], "/"), "/")
+	for j := 1; j < len(segments); j += 2 {
+		if nonIDSegments[segments[j]] {
+			break
+		}
+		segments[j] = ":id"
+	}
+
+	return "/api/" + strings.Join(segments, "/")
+}
+
+// startSpan starts a span for the request when a tracer is set and injects its context into the headers
+func (ci *ConnectionInfo) startSpan(req *http.Request, endpoint string) (*http.Request, Span) {
+
+	if ci.Tracer == nil {
+		return req, nopSpan{}
+	}
+
+	ctx, span := ci.Tracer.Start(req.Context(), "foreman "+req.Method+" "+endpoint, map[string]string{
+		"http.method":      req.Method,
+		"http.url":         scrubURL(req.URL),
+		"foreman.endpoint": endpoint,
+	})
+	req = req.WithContext(ctx)
+	ci.Tracer.Inject(ctx, req.Header)
+
+	return req, span
+}
+
+// nopSpan is used when no tracer is set
+type nopSpan struct{}
+
+func (nopSpan) End(int, error) {}
@@ -0,0 +1,177 @@
+package foreman_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	hooksTimeout = 180
+)
+
+// testTracer records spans and propagates a fixed w3c trace context
+type testTracer struct {
+	spans []string
+}
+
+type testSpan struct {
+	tracer *testTracer
+	name   string
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, attributes map[string]string) (context.Context, foreman.Span) {
+	return ctx, &testSpan{tracer: t, name: name}
+}
+
+func (t *testTracer) Inject(ctx context.Context, header http.Header) {
+	header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+}
+
+func (s *testSpan) End(status int, err error) {
+	s.tracer.spans = append(s.tracer.spans, fmt.Sprintf("%s %d %v", s.name, status, err))
+}
+
+func ExampleMetricsFunc() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(`{"name":"token_duration","value":60}`)))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), hooksTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{BaseURL: server.URL, Client: server.Client()}
+	api.Metrics = foreman.MetricsFunc(func(method string, endpoint string, status int, duration time.Duration, err error) {
+		fmt.Println(method, endpoint, status, err)
+	})
+
+	_, err := api.GetSetting(ctx, "token_duration")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// Output: GET /api/settings/:id 200 <nil>
+}
+
+func TestHooksEndpoints(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(`{"total":1,"subtotal":1,"results":[{"id":4,"name":"web01"}]}`)))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), hooksTimeout*time.Second)
+	defer cancel()
+
+	tt := []struct {
+		name      string
+		call      func(api *foreman.ConnectionInfo) error
+		endpoints []string
+	}{
+		{
+			name:      "Host member action",
+			call:      func(api *foreman.ConnectionInfo) error { return api.PowerHost(ctx, "on") },
+			endpoints: []string{"PUT /api/hosts/:id/power"},
+		},
+		{
+			name: "Bulk action",
+			call: func(api *foreman.ConnectionInfo) error {
+				_, err := api.BulkBuild(ctx, "name = web01", false)
+				return err
+			},
+			endpoints: []string{"GET /api/hosts", "PUT /api/hosts/bulk/build"},
+		},
+		{
+			name: "Collection action",
+			call: func(api *foreman.ConnectionInfo) error {
+				_, err := api.ImportAnsibleRoles(ctx, 1)
+				return err
+			},
+			endpoints: []string{"PUT /api/ansible_roles/import"},
+		},
+		{
+			name: "Nested member",
+			call: func(api *foreman.ConnectionInfo) error {
+				return api.DeleteOverrideValue(ctx, 12, 3)
+			},
+			endpoints: []string{"DELETE /api/smart_class_parameters/:id/override_values/:id"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+
+			var endpoints []string
+			api := foreman.ConnectionInfo{BaseURL: server.URL + "/foreman", Client: server.Client(), Hostname: "web01.example.com"}
+			api.Metrics = foreman.MetricsFunc(func(method string, endpoint string, status int, duration time.Duration, err error) {
+				endpoints = append(endpoints, method+" "+endpoint)
+			})
+
+			err := tc.call(&api)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(endpoints, tc.endpoints) {
+				t.Errorf("expected %v got %v", tc.endpoints, endpoints)
+			}
+		})
+	}
+}
+
+func TestHooksTracer(t *testing.T) {
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		traceparent = req.Header.Get("Traceparent")
+		rw.WriteHeader(http.StatusNotFound)
+		check(rw.Write([]byte(`{"error":{"message":"Resource host not found by id 'web01'"}}`)))
+	}))
+	defer server.Close()
+
+	tracer := &testTracer{}
+	api := foreman.ConnectionInfo{BaseURL: server.URL, Client: server.Client(), Hostname: "web01", Tracer: tracer}
+
+	_, _, err := api.CheckHost(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if traceparent != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+		t.Errorf("expected trace context to be propagated got %q", traceparent)
+	}
+	expected := []string{"foreman GET /api/hosts/:id 404 <nil>"}
+	if !reflect.DeepEqual(tracer.spans, expected) {
+		t.Errorf("expected spans %v got %v", expected, tracer.spans)
+	}
+}
+
+func TestHooksTransportError(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	server.Close()
+
+	var status int
+	var observed error
+	tracer := &testTracer{}
+	api := foreman.ConnectionInfo{BaseURL: server.URL, Tracer: tracer}
+	api.Metrics = foreman.MetricsFunc(func(method string, endpoint string, s int, duration time.Duration, err error) {
+		status, observed = s, err
+	})
+
+	_, err := api.ListSettings(context.Background(), "")
+	if err == nil || !errors.Is(err, observed) {
+		t.Errorf("expected transport error to be observed got %v %v", err, observed)
+	}
+	if status != 0 || len(tracer.spans) != 1 {
+		t.Errorf("expected status 0 and one span got %d %v", status, tracer.spans)
+	}
+}
@@ -4,14 +4,14 @@ Package foreman provide a client to the Foreman API.
 package foreman
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"io/ioutil"
-	"log"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"net/url"
-	"path"
+	"strconv"
 	"strings"
 )
 
@@ -30,7 +30,7 @@ type hostsReqBody struct {
 	Name                    string            `json:"name"`
 	HostgroupID             int               `json:"hostgroup_id"`
 	OrganisationID          int               `json:"organization_id"`
-	LocationID         		int               `json:"location_id"`
+	LocationID              int               `json:"location_id"`
 	Managed                 bool              `json:"managed"`
 	ComputeProfileID        string            `json:"compute_profile_id"`
 	ProvisionMethod         string            `json:"provision_method"`
@@ -40,23 +40,16 @@ type hostsReqBody struct {
 	Overwrite               string            `json:"overwrite"`
 	ComputeAttributes       map[string]string `json:"compute_attributes"`
 	HostParameterAttributes interface{}       `json:"host_parameters_attributes"`
+	OperatingSystemID       int               `json:"operatingsystem_id,omitempty"`
+	ArchitectureID          int               `json:"architecture_id,omitempty"`
+	MediumID                int               `json:"medium_id,omitempty"`
+	PtableID                int               `json:"ptable_id,omitempty"`
 }
 
 // CheckHost checks if instance already exists
 func (ci *ConnectionInfo) CheckHost(ctx context.Context) (bool, string, error) {
 
-	var jsonData []byte
-	url, _ := url.Parse(ci.BaseURL)
-	url.Path = path.Join(url.Path, hostsapi, ci.Hostname)
-	apiused := url.String()
-	log.Printf("API Used: %s", apiused)
-
-	exists, status, err := sendRequest(ctx, ci.Hostname, ci.Username, ci.Password, ci.Client, jsonData, apiused, http.MethodGet)
-	if err != nil {
-		log.Fatalf("Error: %s", err.Error())
-	}
-
-	return exists, status, err
+	return ci.hostRequest(ctx, http.MethodGet, ci.apiURL(nil, hostsapi, ci.Hostname), nil)
 }
 
 // CreateHost create host with the name provided
@@ -65,7 +58,7 @@ func (ci *ConnectionInfo) CreateHost(ctx context.Context) (bool, string, error)
 	requestBody := hostsReqBody{
 		Name:                    ci.Hostname,
 		HostgroupID:             ci.Group,
-		OrganisationID:			 9,
+		OrganisationID:          9,
 		LocationID:              15,
 		Managed:                 true,
 		ComputeProfileID:        ci.Profile,
@@ -78,24 +71,23 @@ func (ci *ConnectionInfo) CreateHost(ctx context.Context) (bool, string, error)
 		HostParameterAttributes: []interface{}{map[string]string{"name": "disksize", "value": "512"}},
 	}
 
+	err := ci.resolveCatalogs(ctx, &requestBody)
+	if err != nil {
+		return false, "", err
+	}
+
 	requestHead := hostsReq{
 		Host: requestBody,
 	}
 
 	jsonData, err := json.MarshalIndent(requestHead, "", "    ")
-	log.Printf("%s", string(jsonData))
 	if err != nil {
-		log.Fatalf("Error: %s", err)
+		return false, "", err
 	}
 
-	url, _ := url.Parse(ci.BaseURL)
-	url.Path = path.Join(url.Path, hostsapi)
-	apiused := url.String()
-	log.Printf("API Used:(%s) %s", http.MethodPost, apiused)
-
-	exists, _, err := sendRequest(ctx, ci.Hostname, ci.Username, ci.Password, ci.Client, jsonData, apiused, http.MethodPost)
+	exists, _, err := ci.hostRequest(ctx, http.MethodPost, ci.apiURL(nil, hostsapi), jsonData)
 	if err != nil {
-		log.Fatalf("Error: %s", err.Error())
+		return false, "", err
 	}
 	status := "The host [" + ci.Hostname + "] was created successfully"
 
@@ -103,68 +95,240 @@ func (ci *ConnectionInfo) CreateHost(ctx context.Context) (bool, string, error)
 
 }
 
+// resolveCatalogs sets the operating system, architecture, medium and partition table ids from the names provided
+func (ci *ConnectionInfo) resolveCatalogs(ctx context.Context, body *hostsReqBody) error {
+
+	var err error
+
+	if ci.OperatingSystem != "" {
+		body.OperatingSystemID, err = ci.ResolveOperatingSystem(ctx, ci.OperatingSystem)
+		if err != nil {
+			return err
+		}
+	}
+	if ci.Architecture != "" {
+		body.ArchitectureID, err = ci.ResolveArchitecture(ctx, ci.Architecture)
+		if err != nil {
+			return err
+		}
+	}
+	if ci.Medium != "" {
+		body.MediumID, err = ci.ResolveMedium(ctx, ci.Medium)
+		if err != nil {
+			return err
+		}
+	}
+	if ci.PartitionTable != "" {
+		body.PtableID, err = ci.ResolvePartitionTable(ctx, ci.PartitionTable)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // DeleteHost deletes the host with name provided
 func (ci *ConnectionInfo) DeleteHost(ctx context.Context) (bool, string, error) {
 
-	var jsonData []byte
-	url, _ := url.Parse(ci.BaseURL)
-	url.Path = path.Join(url.Path, hostsapi, ci.Hostname)
-	apiused := url.String()
-	log.Printf("API Used: %s", apiused)
+	return ci.hostRequest(ctx, http.MethodDelete, ci.apiURL(nil, hostsapi, ci.Hostname), nil)
+}
+
+// BuildStatus contains the build status of a host
+type BuildStatus struct {
+	Status      int    `json:"status"`
+	StatusLabel string `json:"status_label"`
+}
+
+// RebuildHost puts the host back into build mode, keeping its id, history and reports, and optionally power cycles it
+func (ci *ConnectionInfo) RebuildHost(ctx context.Context, powerCycle bool) error {
 
-	exists, status, err := sendRequest(ctx, ci.Hostname, ci.Username, ci.Password, ci.Client, jsonData, apiused, http.MethodDelete)
+	req := map[string]interface{}{"host": map[string]bool{"build": true}}
+	err := ci.doJSON(ctx, http.MethodPut, ci.apiURL(nil, hostsapi, ci.Hostname), req, nil)
 	if err != nil {
-		log.Fatalf("Error: %s", err.Error())
+		return err
 	}
 
-	return exists, status, err
+	if powerCycle {
+		return ci.PowerHost(ctx, "cycle")
+	}
+
+	return nil
 }
 
-// sendRequest send http request to specified endpoints and returns response
-func sendRequest(ctx context.Context, name string, user string, password string, client *http.Client, data []byte, api string, method string) (bool, string, error) {
+// CancelBuild takes the host out of build mode
+func (ci *ConnectionInfo) CancelBuild(ctx context.Context) error {
 
-	var status string
-	var err error
-	var req *http.Request
-	var exists = true
+	req := map[string]interface{}{"host": map[string]bool{"build": false}}
+	return ci.doJSON(ctx, http.MethodPut, ci.apiURL(nil, hostsapi, ci.Hostname), req, nil)
+}
+
+// PowerHost sends a power action (on, off, soft, cycle, reset, state) to the host
+func (ci *ConnectionInfo) PowerHost(ctx context.Context, action string) error {
 
-	if method == http.MethodGet {
-		req, err = http.NewRequest(http.MethodGet, api, nil)
+	req := map[string]string{"power_action": action}
+	return ci.doJSON(ctx, http.MethodPut, ci.apiURL(nil, hostsapi, ci.Hostname, "power"), req, nil)
+}
 
-	} else if method == http.MethodDelete {
-		req, err = http.NewRequest(http.MethodDelete, api, nil)
+// GetBuildStatus returns the build status of the host
+func (ci *ConnectionInfo) GetBuildStatus(ctx context.Context) (*BuildStatus, error) {
 
-	} else {
-		req, err = http.NewRequest(http.MethodPost, api, bytes.NewBuffer(data))
+	var status BuildStatus
+	err := ci.doJSON(ctx, http.MethodGet, ci.apiURL(nil, hostsapi, ci.Hostname, "status", "build"), nil, &status)
+
+	return &status, err
+}
+
+// RebuildConfigError is returned when foreman fails to rebuild some of the orchestration configuration of a host
+type RebuildConfigError struct {
+	Failed []string
+	Err    *APIError
+}
+
+// Error implements the error interface
+func (e *RebuildConfigError) Error() string {
+	return fmt.Sprintf("rebuild config failed for %s: %s", strings.Join(e.Failed, ", "), e.Err.Error())
+}
+
+// Unwrap returns the api error foreman responded with
+func (e *RebuildConfigError) Unwrap() error {
+	return e.Err
+}
+
+// RebuildConfig rebuilds the orchestration configuration (DHCP, DNS, TFTP...) of the host, only limits the rebuild to the types provided.
+// A RebuildConfigError listing the types that failed is returned when foreman cannot rebuild them
+func (ci *ConnectionInfo) RebuildConfig(ctx context.Context, only ...string) error {
+
+	var query url.Values
+	if len(only) > 0 {
+		query = url.Values{"only[]": only}
 	}
-	if err != nil {
-		log.Fatalf("Error: %s", err.Error())
+
+	var resp struct {
+		Message string `json:"message"`
 	}
+	err := ci.doJSON(ctx, http.MethodPut, ci.apiURL(query, hostsapi, ci.Hostname, "rebuild_config"), nil, &resp)
 
-	req = req.WithContext(ctx)
-	req.SetBasicAuth(user, password)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnprocessableEntity {
+		return &RebuildConfigError{Failed: rebuildFailures(apiErr.Message), Err: apiErr}
+	}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		log.Fatalf("Error: %s", err.Error())
+	return err
+}
+
+// rebuildFailures returns the types listed in a rebuild failure message, e.g. Configuration rebuild failed for: DHCP, DNS and TFTP.
+func rebuildFailures(message string) []string {
+
+	i := strings.LastIndex(message, ":")
+	if i < 0 {
+		return nil
 	}
-	defer resp.Body.Close()
+	list := strings.TrimSuffix(strings.TrimSpace(message[i+1:]), ".")
+	list = strings.NewReplacer(", and ", ",", " and ", ",").Replace(list)
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		log.Fatalf("Error: %s", err.Error())
+	var failed []string
+	for _, f := range strings.Split(list, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			failed = append(failed, f)
+		}
+	}
+
+	return failed
+}
+
+// GetVMComputeAttributes returns the compute attributes of the virtual machine backing the host
+func (ci *ConnectionInfo) GetVMComputeAttributes(ctx context.Context) (map[string]interface{}, error) {
+
+	var attrs map[string]interface{}
+	err := ci.doJSON(ctx, http.MethodGet, ci.apiURL(nil, hostsapi, ci.Hostname, "vm_compute_attributes"), nil, &attrs)
+
+	return attrs, err
+}
+
+// ResolveHost returns the id of the host with the name provided
+func (ci *ConnectionInfo) ResolveHost(ctx context.Context, name string) (int, error) {
+
+	var host struct {
+		ID int `json:"id"`
+	}
+	err := ci.doJSON(ctx, http.MethodGet, ci.apiURL(nil, hostsapi, name), nil, &host)
+
+	return host.ID, err
+}
+
+// GetHostBy returns the host matching the selector, which can be a numeric id, a short name, an fqdn, a mac address or an ip.
+// Selectors that are not a path to the host are resolved through search, an error wrapping ErrAmbiguous is returned when more than one host matches
+func (ci *ConnectionInfo) GetHostBy(ctx context.Context, selector string) (*Host, error) {
+
+	selector = strings.TrimSpace(selector)
+	if selector == "" {
+		return nil, errors.New("host selector needs to be provided")
+	}
+
+	var search string
+	var match func(h Host) bool
+
+	// dotted macs such as 0000.5e00.5301 are left out, they cannot be told apart from a name
+	if mac, err := net.ParseMAC(selector); err == nil && strings.ContainsAny(selector, ":-") {
+		search = fmt.Sprintf("mac = %q", mac.String())
+		match = func(h Host) bool { return strings.EqualFold(h.MAC, mac.String()) }
+	} else if ip := net.ParseIP(selector); ip != nil {
+		search = fmt.Sprintf("ip = %q", ip.String())
+		match = func(h Host) bool { return h.IP == ip.String() || h.IP6 == ip.String() }
+		if ip.To4() == nil {
+			search = fmt.Sprintf("ip6 = %q", ip.String())
+		}
 	} else {
-		status = string(body)
-		if strings.Contains(status, message) {
-			exists = false
+		host, err := ci.HostsResource().Get(ctx, selector)
+		var apiErr *APIError
+		if err == nil || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			return host, err
 		}
+		if _, err := strconv.Atoi(selector); err == nil || strings.Contains(selector, ".") {
+			return nil, fmt.Errorf("host [%s] %w", selector, ErrNotFound)
+		}
+		search = fmt.Sprintf("name ~ %q", selector+".")
+		match = func(h Host) bool { return strings.HasPrefix(h.Name, selector+".") }
 	}
-	if resp.StatusCode != 200 && resp.StatusCode != 201 && resp.StatusCode != 404 {
-		log.Printf("Http status code is: %v", resp.StatusCode)
-		log.Printf("Http request sent was: %v", resp.Request)
+
+	hosts, err := ci.HostsResource().List(ctx, search)
+	if err != nil {
+		return nil, err
 	}
 
-	return exists, status, err
-}
\ No newline at end of file
+	var names []string
+	var found Host
+	for _, h := range hosts {
+		if match(h) {
+			names = append(names, h.Name)
+			found = h
+		}
+	}
+
+	switch len(names) {
+	case 0:
+		return nil, fmt.Errorf("host [%s] %w", selector, ErrNotFound)
+	case 1:
+		return ci.HostsResource().Get(ctx, strconv.Itoa(found.ID))
+	default:
+		return nil, fmt.Errorf("host [%s] %w, %d matches found: %s", selector, ErrAmbiguous, len(names), strings.Join(names, ", "))
+	}
+}
+
+// hostRequest sends the request for a host and reports whether the host exists along with the response body,
+// a not found response is reported as a missing host rather than as an error
+func (ci *ConnectionInfo) hostRequest(ctx context.Context, method string, api requestURL, data []byte) (bool, string, error) {
+
+	body, err := ci.doRequest(ctx, method, api, data)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return false, string(body), nil
+	}
+	if err != nil {
+		return false, "", err
+	}
+
+	status := string(body)
+	return !strings.Contains(status, message), status, nil
+}
@@ -2,6 +2,7 @@ package foreman_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/bishy999/go-foreman/pkg/foreman"
+	"github.com/bishy999/go-foreman/pkg/foremantest"
 )
 
 const (
@@ -202,3 +204,181 @@ func check(n int, err error) {
         log.Printf("Write failed: %v", err)
     }
 }
+
+func TestRebuildHost(t *testing.T) {
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		check(rw.Write([]byte(`{"id":1,"build":true}`)))
+	}))
+	defer server.Close()
+
+	tt := []struct {
+		name           string
+		powercycle     bool
+		expectedresult []string
+	}{
+		{name: "rebuild", powercycle: false, expectedresult: []string{"PUT /api/hosts/test"}},
+		{name: "rebuild and power cycle", powercycle: true, expectedresult: []string{"PUT /api/hosts/test", "PUT /api/hosts/test/power"}},
+	}
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, hostsTimeout*time.Second)
+	defer cancel()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			requests = nil
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client(), Hostname: "test"}
+			err := api.RebuildHost(ctx, tc.powercycle)
+			if err != nil {
+				t.Fatalf("Could not read response %v correctly", err)
+			}
+			if fmt.Sprint(tc.expectedresult) != fmt.Sprint(requests) {
+				t.Errorf("Test %v result should be %v, got  `%v`", tc.name, tc.expectedresult, requests)
+			}
+		})
+	}
+}
+
+func ExampleConnectionInfo_RebuildConfig() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(`{"message":"Configuration successfully rebuilt."}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, hostsTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client(), Hostname: "test"}
+	err := api.RebuildConfig(ctx, "DHCP", "TFTP")
+
+	fmt.Printf("%v", err)
+
+	// Output: <nil>
+}
+
+func TestRebuildConfigFailed(t *testing.T) {
+
+	tt := []struct {
+		name           string
+		message        string
+		expectedresult []string
+	}{
+		{name: "one type", message: "Configuration rebuild failed for: DHCP.", expectedresult: []string{"DHCP"}},
+		{name: "two types", message: "Configuration rebuild failed for: DHCP and TFTP.", expectedresult: []string{"DHCP", "TFTP"}},
+		{name: "three types", message: "Configuration rebuild failed for: DHCP, DNS, and TFTP.", expectedresult: []string{"DHCP", "DNS", "TFTP"}},
+	}
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, hostsTimeout*time.Second)
+	defer cancel()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				check(rw.Write([]byte(`{"error":{"message":"` + tc.message + `"}}`)))
+			}))
+			defer server.Close()
+
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client(), Hostname: "test"}
+			err := api.RebuildConfig(ctx)
+
+			var rebuildErr *foreman.RebuildConfigError
+			if !errors.As(err, &rebuildErr) {
+				t.Fatalf("Test %v result should be a RebuildConfigError, got `%v`", tc.name, err)
+			}
+			if fmt.Sprint(rebuildErr.Failed) != fmt.Sprint(tc.expectedresult) {
+				t.Errorf("Test %v result should be %v, got `%v`", tc.name, tc.expectedresult, rebuildErr.Failed)
+			}
+			var apiErr *foreman.APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+				t.Errorf("Test %v should wrap the api error, got `%v`", tc.name, err)
+			}
+		})
+	}
+}
+
+func ExampleConnectionInfo_GetHostBy() {
+
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	server.AddHost(foremantest.Host{ID: 42, Name: "dev01.example.com", MAC: "52:54:00:12:34:56", IP: "10.0.0.15"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), hostsTimeout*time.Second)
+	defer cancel()
+
+	api := server.ConnectionInfo()
+
+	host, err := api.GetHostBy(ctx, "52:54:00:12:34:56")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(host.ID, host.Name)
+	// Output: 42 dev01.example.com
+}
+
+func TestGetHostBy(t *testing.T) {
+
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	server.AddHost(foremantest.Host{ID: 1, Name: "dev01.example.com", MAC: "52:54:00:00:00:01", IP: "10.0.0.1"})
+	server.AddHost(foremantest.Host{ID: 2, Name: "dev02.example.com", MAC: "52:54:00:00:00:02", IP: "10.0.0.2"})
+	server.AddHost(foremantest.Host{ID: 3, Name: "dev02.example.org", MAC: "52:54:00:00:00:03", IP: "10.0.0.3"})
+	server.AddHost(foremantest.Host{ID: 4, Name: "mydev01.example.com", MAC: "52:54:00:00:00:04", IP: "10.0.0.4"})
+	server.AddHost(foremantest.Host{ID: 5, Name: "bare", MAC: "52:54:00:00:00:05", IP: "10.0.0.5"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), hostsTimeout*time.Second)
+	defer cancel()
+
+	api := server.ConnectionInfo()
+
+	tt := []struct {
+		name        string
+		selector    string
+		expectedid  int
+		expectederr error
+	}{
+		{name: "id", selector: "2", expectedid: 2},
+		{name: "fqdn", selector: "dev02.example.org", expectedid: 3},
+		{name: "short name", selector: "dev01", expectedid: 1},
+		{name: "short name without domain", selector: "bare", expectedid: 5},
+		{name: "mac", selector: "52:54:00:00:00:04", expectedid: 4},
+		{name: "mac upper case", selector: "52-54-00-00-00-04", expectedid: 4},
+		{name: "ip", selector: "10.0.0.3", expectedid: 3},
+		{name: "short name ambiguous", selector: "dev02", expectederr: foreman.ErrAmbiguous},
+		{name: "unknown id", selector: "99", expectederr: foreman.ErrNotFound},
+		{name: "unknown fqdn", selector: "dev09.example.com", expectederr: foreman.ErrNotFound},
+		{name: "unknown short name", selector: "dev09", expectederr: foreman.ErrNotFound},
+		{name: "unknown mac", selector: "52:54:00:00:00:99", expectederr: foreman.ErrNotFound},
+		{name: "unknown ip", selector: "10.0.0.99", expectederr: foreman.ErrNotFound},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+
+			host, err := api.GetHostBy(ctx, tc.selector)
+			if tc.expectederr != nil {
+				if !errors.Is(err, tc.expectederr) {
+					t.Fatalf("Test %v result should be %v, got  `%v`", tc.name, tc.expectederr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Test %v unexpected error `%v`", tc.name, err)
+			}
+			if host.ID != tc.expectedid {
+				t.Errorf("Test %v result should be host %v, got  `%v` %v", tc.name, tc.expectedid, host.ID, host.Name)
+			}
+		})
+	}
+}
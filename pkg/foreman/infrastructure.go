@@ -0,0 +1,203 @@
+package foreman
+
+const (
+	computeprofilesapi  = "api/compute_profiles"
+	computeresourcesapi = "api/compute_resources"
+	domainsapi          = "api/domains"
+	subnetsapi          = "api/subnets"
+	realmsapi           = "api/realms"
+	modelsapi           = "api/models"
+	commonparametersapi = "api/common_parameters"
+)
+
+// Host represents a foreman host
+type Host struct {
+	ID                int    `json:"id,omitempty"`
+	Name              string `json:"name,omitempty"`
+	IP                string `json:"ip,omitempty"`
+	IP6               string `json:"ip6,omitempty"`
+	MAC               string `json:"mac,omitempty"`
+	Comment           string `json:"comment,omitempty"`
+	Enabled           *bool  `json:"enabled,omitempty"`
+	Managed           *bool  `json:"managed,omitempty"`
+	Build             *bool  `json:"build,omitempty"`
+	HostgroupID       int    `json:"hostgroup_id,omitempty"`
+	OrganizationID    int    `json:"organization_id,omitempty"`
+	LocationID        int    `json:"location_id,omitempty"`
+	ComputeProfileID  int    `json:"compute_profile_id,omitempty"`
+	ComputeResourceID int    `json:"compute_resource_id,omitempty"`
+	DomainID          int    `json:"domain_id,omitempty"`
+	SubnetID          int    `json:"subnet_id,omitempty"`
+	OperatingSystemID int    `json:"operatingsystem_id,omitempty"`
+	ArchitectureID    int    `json:"architecture_id,omitempty"`
+	MediumID          int    `json:"medium_id,omitempty"`
+	PtableID          int    `json:"ptable_id,omitempty"`
+	ModelID           int    `json:"model_id,omitempty"`
+	RealmID           int    `json:"realm_id,omitempty"`
+	GlobalStatus      int    `json:"global_status,omitempty"`
+	CreatedAt         string `json:"created_at,omitempty"`
+	UpdatedAt         string `json:"updated_at,omitempty"`
+}
+
+// Hostgroup represents a foreman hostgroup
+type Hostgroup struct {
+	ID                int    `json:"id,omitempty"`
+	Name              string `json:"name,omitempty"`
+	Title             string `json:"title,omitempty"`
+	Description       string `json:"description,omitempty"`
+	ParentID          int    `json:"parent_id,omitempty"`
+	ComputeProfileID  int    `json:"compute_profile_id,omitempty"`
+	DomainID          int    `json:"domain_id,omitempty"`
+	SubnetID          int    `json:"subnet_id,omitempty"`
+	OperatingSystemID int    `json:"operatingsystem_id,omitempty"`
+	ArchitectureID    int    `json:"architecture_id,omitempty"`
+	MediumID          int    `json:"medium_id,omitempty"`
+	PtableID          int    `json:"ptable_id,omitempty"`
+	EnvironmentID     int    `json:"environment_id,omitempty"`
+}
+
+// ComputeProfile represents a foreman compute profile, e.g. small or large
+type ComputeProfile struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// ComputeResource represents a virtualization or cloud provider foreman provisions hosts on
+type ComputeResource struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Provider    string `json:"provider,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Domain represents a foreman dns domain
+type Domain struct {
+	ID        int    `json:"id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Fullname  string `json:"fullname,omitempty"`
+	DNSID     int    `json:"dns_id,omitempty"`
+	SubnetIDs []int  `json:"subnet_ids,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// Subnet represents a foreman ipv4 or ipv6 subnet
+type Subnet struct {
+	ID           int    `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	NetworkType  string `json:"network_type,omitempty"`
+	Network      string `json:"network,omitempty"`
+	Mask         string `json:"mask,omitempty"`
+	CIDR         int    `json:"cidr,omitempty"`
+	Gateway      string `json:"gateway,omitempty"`
+	DNSPrimary   string `json:"dns_primary,omitempty"`
+	DNSSecondary string `json:"dns_secondary,omitempty"`
+	From         string `json:"from,omitempty"`
+	To           string `json:"to,omitempty"`
+	VLANID       int    `json:"vlanid,omitempty"`
+	Boot         string `json:"boot_mode,omitempty"`
+	IPAM         string `json:"ipam,omitempty"`
+	DomainIDs    []int  `json:"domain_ids,omitempty"`
+	DHCPID       int    `json:"dhcp_id,omitempty"`
+	TFTPID       int    `json:"tftp_id,omitempty"`
+	DNSID        int    `json:"dns_id,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// SmartProxy represents a foreman smart proxy
+type SmartProxy struct {
+	ID       int    `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Features []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"features,omitempty"`
+}
+
+// Realm represents a foreman identity realm, e.g. FreeIPA
+type Realm struct {
+	ID           int    `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	RealmType    string `json:"realm_type,omitempty"`
+	RealmProxyID int    `json:"realm_proxy_id,omitempty"`
+}
+
+// Model represents a foreman hardware model
+type Model struct {
+	ID            int    `json:"id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	VendorClass   string `json:"vendor_class,omitempty"`
+	HardwareModel string `json:"hardware_model,omitempty"`
+	Info          string `json:"info,omitempty"`
+}
+
+// Parameter represents a foreman parameter, either global or attached to a host, hostgroup or taxonomy
+type Parameter struct {
+	ID            int    `json:"id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Value         string `json:"value,omitempty"`
+	ParameterType string `json:"parameter_type,omitempty"`
+	HiddenValue   *bool  `json:"hidden_value,omitempty"`
+}
+
+// HostsResource returns the typed resource for hosts
+func (ci *ConnectionInfo) HostsResource() *Resource[Host] {
+	return NewResource[Host](ci, "host", hostsapi)
+}
+
+// HostgroupsResource returns the typed resource for hostgroups
+func (ci *ConnectionInfo) HostgroupsResource() *Resource[Hostgroup] {
+	return NewResource[Hostgroup](ci, "hostgroup", hostgroupsapi)
+}
+
+// ComputeProfilesResource returns the typed resource for compute profiles
+func (ci *ConnectionInfo) ComputeProfilesResource() *Resource[ComputeProfile] {
+	return NewResource[ComputeProfile](ci, "compute_profile", computeprofilesapi)
+}
+
+// ComputeResourcesResource returns the typed resource for compute resources
+func (ci *ConnectionInfo) ComputeResourcesResource() *Resource[ComputeResource] {
+	return NewResource[ComputeResource](ci, "compute_resource", computeresourcesapi)
+}
+
+// DomainsResource returns the typed resource for domains
+func (ci *ConnectionInfo) DomainsResource() *Resource[Domain] {
+	return NewResource[Domain](ci, "domain", domainsapi)
+}
+
+// SubnetsResource returns the typed resource for subnets
+func (ci *ConnectionInfo) SubnetsResource() *Resource[Subnet] {
+	return NewResource[Subnet](ci, "subnet", subnetsapi)
+}
+
+// SmartProxiesResource returns the typed resource for smart proxies
+func (ci *ConnectionInfo) SmartProxiesResource() *Resource[SmartProxy] {
+	return NewResource[SmartProxy](ci, "smart_proxy", smartproxiesapi)
+}
+
+// RealmsResource returns the typed resource for realms
+func (ci *ConnectionInfo) RealmsResource() *Resource[Realm] {
+	return NewResource[Realm](ci, "realm", realmsapi)
+}
+
+// ModelsResource returns the typed resource for hardware models
+func (ci *ConnectionInfo) ModelsResource() *Resource[Model] {
+	return NewResource[Model](ci, "model", modelsapi)
+}
+
+// CommonParametersResource returns the typed resource for global parameters
+func (ci *ConnectionInfo) CommonParametersResource() *Resource[Parameter] {
+	return NewResource[Parameter](ci, "common_parameter", commonparametersapi)
+}
+
+// HostParametersResource returns the typed resource for the parameters of the host with the id or name provided
+func (ci *ConnectionInfo) HostParametersResource(host string) *Resource[Parameter] {
+	return NewResource[Parameter](ci, "parameter", hostsapi, host, "parameters")
+}
+
+// HostgroupParametersResource returns the typed resource for the parameters of the hostgroup with the id provided
+func (ci *ConnectionInfo) HostgroupParametersResource(hostgroup string) *Resource[Parameter] {
+	return NewResource[Parameter](ci, "parameter", hostgroupsapi, hostgroup, "parameters")
+}
@@ -0,0 +1,148 @@
+package foreman
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	jobtemplatesapi   = "api/job_templates"
+	jobinvocationsapi = "api/job_invocations"
+)
+
+// JobTemplate represents a remote execution job template
+type JobTemplate struct {
+	ID                int    `json:"id,omitempty"`
+	Name              string `json:"name,omitempty"`
+	JobCategory       string `json:"job_category,omitempty"`
+	ProviderType      string `json:"provider_type,omitempty"`
+	DescriptionFormat string `json:"description_format,omitempty"`
+	Snippet           *bool  `json:"snippet,omitempty"`
+}
+
+// JobInvocation represents a remote execution job run against the hosts matching a search query
+type JobInvocation struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+	JobCategory string `json:"job_category"`
+	Status      int    `json:"status"`
+	StatusLabel string `json:"status_label"`
+	Succeeded   int    `json:"succeeded"`
+	Failed      int    `json:"failed"`
+	Pending     int    `json:"pending"`
+	Total       int    `json:"total"`
+	Task        struct {
+		ID    string `json:"id"`
+		State string `json:"state"`
+	} `json:"task"`
+}
+
+// JobInvocationHost is a host targeted by a job invocation
+type JobInvocationHost struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	JobStatus string `json:"job_status"`
+}
+
+// JobOutput contains the output of a job invocation on a single host
+type JobOutput struct {
+	Complete bool `json:"complete"`
+	Output   []struct {
+		Output     string  `json:"output"`
+		OutputType string  `json:"output_type"`
+		Timestamp  float64 `json:"timestamp"`
+	} `json:"output"`
+}
+
+// jobInvocationReq contains fields neccessary for creating a job invocation
+type jobInvocationReq struct {
+	JobTemplateID int               `json:"job_template_id"`
+	TargetingType string            `json:"targeting_type"`
+	SearchQuery   string            `json:"search_query"`
+	Inputs        map[string]string `json:"inputs,omitempty"`
+}
+
+// Done reports whether the job invocation has finished on every host
+func (j *JobInvocation) Done() bool {
+	return j.Pending == 0 && j.StatusLabel != "queued" && j.StatusLabel != "running"
+}
+
+// String joins the output lines of the job
+func (o *JobOutput) String() string {
+	var out string
+	for _, line := range o.Output {
+		out += line.Output
+	}
+	return out
+}
+
+// JobTemplatesResource returns the typed resource for job templates
+func (ci *ConnectionInfo) JobTemplatesResource() *Resource[JobTemplate] {
+	return NewResource[JobTemplate](ci, "job_template", jobtemplatesapi)
+}
+
+// JobInvocationsResource returns the typed read only resource for job invocations, they are started with CreateJobInvocation
+func (ci *ConnectionInfo) JobInvocationsResource() *ReadOnlyResource[JobInvocation] {
+	return NewReadOnlyResource[JobInvocation](ci, jobinvocationsapi)
+}
+
+// ListJobTemplates returns the job templates matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListJobTemplates(ctx context.Context, search string) ([]JobTemplate, error) {
+	return ci.JobTemplatesResource().List(ctx, search)
+}
+
+// ResolveJobTemplate returns the id of the job template with the name provided
+func (ci *ConnectionInfo) ResolveJobTemplate(ctx context.Context, name string) (int, error) {
+	return ci.JobTemplatesResource().Resolve(ctx, name)
+}
+
+// CreateJobInvocation runs the job template against the hosts matching the search query with the inputs provided
+func (ci *ConnectionInfo) CreateJobInvocation(ctx context.Context, templateID int, search string, inputs map[string]string) (*JobInvocation, error) {
+
+	req := map[string]jobInvocationReq{"job_invocation": {
+		JobTemplateID: templateID,
+		TargetingType: "static_query",
+		SearchQuery:   search,
+		Inputs:        inputs,
+	}}
+
+	return call[JobInvocation](ctx, ci, http.MethodPost, req, jobinvocationsapi)
+}
+
+// GetJobInvocation returns the job invocation with the id provided
+func (ci *ConnectionInfo) GetJobInvocation(ctx context.Context, id int) (*JobInvocation, error) {
+	return ci.JobInvocationsResource().Get(ctx, strconv.Itoa(id))
+}
+
+// WaitJobInvocation polls the job invocation every interval until it has finished or the context is done
+func (ci *ConnectionInfo) WaitJobInvocation(ctx context.Context, id int, interval time.Duration) (*JobInvocation, error) {
+
+	var job *JobInvocation
+
+	err := Wait(ctx, interval, func(ctx context.Context) (bool, error) {
+		var err error
+		job, err = ci.GetJobInvocation(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		return job.Done(), nil
+	})
+
+	return job, err
+}
+
+// ListJobInvocationHosts returns the hosts targeted by the job invocation
+func (ci *ConnectionInfo) ListJobInvocationHosts(ctx context.Context, id int) ([]JobInvocationHost, error) {
+	var hosts []JobInvocationHost
+	err := ci.list(ctx, "", &hosts, jobinvocationsapi, strconv.Itoa(id), "hosts")
+	return hosts, err
+}
+
+// GetJobOutput returns the output of the job invocation on the host provided
+func (ci *ConnectionInfo) GetJobOutput(ctx context.Context, id int, hostID int) (*JobOutput, error) {
+	var output JobOutput
+	err := ci.doJSON(ctx, http.MethodGet, ci.apiURL(nil, jobinvocationsapi, strconv.Itoa(id), "hosts", strconv.Itoa(hostID)), nil, &output)
+	return &output, err
+}
@@ -0,0 +1,91 @@
+package foreman_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	jobsTimeout = 180
+)
+
+func ExampleConnectionInfo_CreateJobInvocation() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		log.Printf("Request: %s", body)
+		check(rw.Write([]byte(`{"id":5,"description":"Run uptime","status_label":"queued","pending":1,"total":1}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, jobsTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	job, err := api.CreateJobInvocation(ctx, 3, "name ~ dev", map[string]string{"command": "uptime"})
+
+	fmt.Printf("%d %s %t %v", job.ID, job.Description, job.Done(), err)
+
+	// Output: 5 Run uptime false <nil>
+}
+
+func TestWaitJobInvocation(t *testing.T) {
+
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		polls++
+		if polls < 3 {
+			check(rw.Write([]byte(`{"id":5,"status_label":"running","pending":1,"total":1}`)))
+			return
+		}
+		check(rw.Write([]byte(`{"id":5,"status_label":"succeeded","succeeded":1,"pending":0,"total":1}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, jobsTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	job, err := api.WaitJobInvocation(ctx, 5, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Could not read response %v correctly", err)
+	}
+	if !job.Done() || job.Succeeded != 1 || polls != 3 {
+		t.Errorf("job should have succeeded after 3 polls, got `%v` after %d", job, polls)
+	}
+}
+
+func TestGetJobOutput(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/job_invocations/5/hosts/7" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		check(rw.Write([]byte(`{"complete":true,"output":[{"output":"up 3 days\n","output_type":"stdout"},{"output":"Exit status: 0","output_type":"debug"}]}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, jobsTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	output, err := api.GetJobOutput(ctx, 5, 7)
+	if err != nil {
+		t.Fatalf("Could not read response %v correctly", err)
+	}
+	if !output.Complete || output.String() != "up 3 days\nExit status: 0" {
+		t.Errorf("unexpected output `%v`", output.String())
+	}
+}
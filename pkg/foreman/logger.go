@@ -0,0 +1,122 @@
+package foreman
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+const (
+	// LevelDebug logs every request, its payload and headers
+	LevelDebug Level = -4
+	// LevelInfo logs the outcome of actions
+	LevelInfo Level = 0
+	// LevelWarn logs server errors returned by foreman
+	LevelWarn Level = 4
+	// LevelError logs requests that could not be sent
+	LevelError Level = 8
+)
+
+// Level is the severity of a log entry, the values match those of log/slog
+type Level int
+
+// Logger receives the structured log entries of the client, args are alternating keys and values.
+// A *slog.Logger satisfies it, a nil Logger on ConnectionInfo discards every entry
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// String returns the name of the level
+func (l Level) String() string {
+	switch {
+	case l < LevelInfo:
+		return "DEBUG"
+	case l < LevelWarn:
+		return "INFO"
+	case l < LevelError:
+		return "WARN"
+	default:
+		return "ERROR"
+	}
+}
+
+// ParseLevel returns the level with the name provided, e.g. debug or WARN
+func ParseLevel(name string) (Level, error) {
+	for _, l := range []Level{LevelDebug, LevelInfo, LevelWarn, LevelError} {
+		if strings.EqualFold(name, l.String()) {
+			return l, nil
+		}
+	}
+	return LevelInfo, fmt.Errorf("unknown log level [%s]", name)
+}
+
+// StdLogger writes entries at or above its level to a standard library logger as key=value pairs
+type StdLogger struct {
+	Logger *log.Logger
+	Level  Level
+}
+
+// NewStdLogger returns a logger writing to l the entries at or above level
+func NewStdLogger(l *log.Logger, level Level) *StdLogger {
+	return &StdLogger{Logger: l, Level: level}
+}
+
+// Debug implements Logger
+func (l *StdLogger) Debug(msg string, args ...interface{}) { l.log(LevelDebug, msg, args) }
+
+// Info implements Logger
+func (l *StdLogger) Info(msg string, args ...interface{}) { l.log(LevelInfo, msg, args) }
+
+// Warn implements Logger
+func (l *StdLogger) Warn(msg string, args ...interface{}) { l.log(LevelWarn, msg, args) }
+
+// Error implements Logger
+func (l *StdLogger) Error(msg string, args ...interface{}) { l.log(LevelError, msg, args) }
+
+// log formats the entry as level=INFO msg="..." key=value
+func (l *StdLogger) log(level Level, msg string, args []interface{}) {
+
+	if level < l.Level {
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "level=%s msg=%s", level, quote(msg))
+	for i := 0; i < len(args); i += 2 {
+		key := fmt.Sprint(args[i])
+		if i+1 == len(args) {
+			fmt.Fprintf(&b, " !BADKEY=%s", quote(key))
+			break
+		}
+		fmt.Fprintf(&b, " %s=%s", key, quote(fmt.Sprint(args[i+1])))
+	}
+
+	l.Logger.Print(b.String())
+}
+
+// quote returns the value quoted when it is empty or contains spaces, quotes or equals signs
+func quote(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+// nopLogger discards every entry
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// logger returns the logger of the connection, or one that discards entries when none is set
+func (ci *ConnectionInfo) logger() Logger {
+	if ci.Logger == nil {
+		return nopLogger{}
+	}
+	return ci.Logger
+}
@@ -0,0 +1,167 @@
+package foreman_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	loggerTimeout = 180
+)
+
+func ExampleNewStdLogger() {
+
+	logger := foreman.NewStdLogger(log.New(os.Stdout, "", 0), foreman.LevelInfo)
+
+	logger.Debug("not shown")
+	logger.Info("host created", "name", "dev01", "hostgroup", "web servers")
+
+	// Output: level=INFO msg="host created" name=dev01 hostgroup="web servers"
+}
+
+func TestLoggerRedaction(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("X-Request-Id", "2f1c6a")
+		check(rw.Write([]byte(`{"id":3,"login":"jdoe","parameters":[{"name":"db_password_hash","value":"abc123","hidden_value":true}]}`)))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	api := foreman.ConnectionInfo{
+		Username: "admin",
+		Password: "hunter2",
+		BaseURL:  server.URL,
+		Client:   server.Client(),
+		Logger:   foreman.NewStdLogger(log.New(&buf, "", 0), foreman.LevelDebug),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), loggerTimeout*time.Second)
+	defer cancel()
+
+	_, err := api.CreateUser(ctx, foreman.User{Login: "jdoe", Password: "s3cret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"method=POST", "status=200", "request_id=2f1c6a", "duration=", "Authorization:[[FILTERED]]"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected log to contain %q got %s", want, out)
+		}
+	}
+	for _, secret := range []string{"hunter2", "s3cret", "Basic "} {
+		if strings.Contains(out, secret) {
+			t.Errorf("expected log not to contain %q got %s", secret, out)
+		}
+	}
+}
+
+func TestLoggerLevels(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+		check(rw.Write([]byte(`{"error":{"message":"boom","parameters":[{"name":"root_pass","value":"abc123","hidden_value":true}]}}`)))
+	}))
+	defer server.Close()
+
+	tt := []struct {
+		name     string
+		level    foreman.Level
+		expected []string
+	}{
+		{name: "Debug", level: foreman.LevelDebug, expected: []string{`level=DEBUG msg="foreman request"`, `level=WARN msg="foreman response"`}},
+		{name: "Warn", level: foreman.LevelWarn, expected: []string{`level=WARN msg="foreman response" method=GET`}},
+		{name: "Error", level: foreman.LevelError},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+
+			var buf bytes.Buffer
+			api := foreman.ConnectionInfo{BaseURL: server.URL, Client: server.Client(), Logger: foreman.NewStdLogger(log.New(&buf, "", 0), tc.level)}
+
+			_, err := api.ListSettings(context.Background(), "")
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if strings.Contains(buf.String(), "abc123") {
+				t.Errorf("expected hidden value to be redacted got %s", buf.String())
+			}
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if buf.Len() == 0 {
+				lines = nil
+			}
+			if len(lines) != len(tc.expected) {
+				t.Fatalf("expected %d lines got %q", len(tc.expected), lines)
+			}
+			for i, want := range tc.expected {
+				if !strings.HasPrefix(lines[i], want) {
+					t.Errorf("expected line %d to start with %q got %q", i, want, lines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLoggerSilentByDefault(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(`{"result":"ok"}`)))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	api := foreman.ConnectionInfo{Username: "admin", Password: "hunter2", BaseURL: server.URL, Client: server.Client(), Hostname: "dev01"}
+
+	_, _, err := api.CheckStatus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = api.CreateHost(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("expected no output got %s", buf.String())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+
+	tt := []struct {
+		name     string
+		expected foreman.Level
+		err      bool
+	}{
+		{name: "debug", expected: foreman.LevelDebug},
+		{name: "INFO", expected: foreman.LevelInfo},
+		{name: "Warn", expected: foreman.LevelWarn},
+		{name: "error", expected: foreman.LevelError},
+		{name: "verbose", expected: foreman.LevelInfo, err: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			level, err := foreman.ParseLevel(tc.name)
+			if level != tc.expected || (err != nil) != tc.err {
+				t.Errorf("expected %v %t got %v %v", tc.expected, tc.err, level, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package foreman
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	requestIDHeader = "X-Request-Id"
+)
+
+// Doer sends an http request, *http.Client implements it
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to the Doer interface
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do implements Doer
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps every api request and response, it may change the request, the response or call next more than once
+type Middleware func(next Doer) Doer
+
+// Chain composes the middlewares in order, the first sees the request first and the response last
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next Doer) Doer {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// UserAgent sets the User-Agent header of every request
+func UserAgent(userAgent string) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("User-Agent", userAgent)
+			return next.Do(req)
+		})
+	}
+}
+
+// RequestID sets a random X-Request-Id header on requests without one, foreman logs it with the request it handles
+func RequestID() Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(requestIDHeader) == "" {
+				id := make([]byte, 16)
+				_, err := rand.Read(id)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(requestIDHeader, hex.EncodeToString(id))
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+// Timeout limits the time a single request, including reading its response body, may take
+func Timeout(timeout time.Duration) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			ctx, cancel := context.WithTimeout(req.Context(), timeout)
+			resp, err := next.Do(req.WithContext(ctx))
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			resp.Body = onClose(resp.Body, cancel)
+			return resp, nil
+		})
+	}
+}
+
+// Retry resends requests that failed to connect or were answered with 429, 502, 503 or 504, up to attempts times in total.
+// Only GET and HEAD requests are retried unless methods are given, foreman PUT and DELETE calls such as power or
+// rebuild_config are not safe to repeat so they are only retried when listed.
+// The wait starts at backoff and doubles, a Retry-After header in seconds is honoured
+func Retry(attempts int, backoff time.Duration, methods ...string) Middleware {
+
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodHead}
+	}
+	retried := map[string]bool{}
+	for _, m := range methods {
+		retried[strings.ToUpper(m)] = true
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+
+			rewindable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+			if !retried[req.Method] || !rewindable {
+				return next.Do(req)
+			}
+
+			wait := backoff
+			for attempt := 1; ; attempt++ {
+
+				try := req
+				if attempt > 1 && req.GetBody != nil {
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, err
+					}
+					try = req.Clone(req.Context())
+					try.Body = body
+				}
+
+				resp, err := next.Do(try)
+				if attempt >= attempts || !retryable(resp, err) || req.Context().Err() != nil {
+					return resp, err
+				}
+
+				delay := wait
+				if resp != nil {
+					if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+						delay = time.Duration(seconds) * time.Second
+					}
+					_, _ = io.Copy(ioutil.Discard, resp.Body)
+					resp.Body.Close()
+				}
+
+				timer := time.NewTimer(delay)
+				select {
+				case <-timer.C:
+				case <-req.Context().Done():
+					timer.Stop()
+					return nil, req.Context().Err()
+				}
+				wait *= 2
+			}
+		})
+	}
+}
+
+// retryable reports whether the outcome of a request is worth retrying
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// Logging logs every request and its response with credentials and hidden values redacted,
+// it is added after the middlewares of the connection when a Logger is set
+func Logging(logger Logger) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+
+			fields := []interface{}{"method", req.Method, "url", scrubURL(req.URL)}
+			if data := requestBody(req); len(data) > 0 {
+				logger.Debug("foreman request", append(fields, "headers", scrubHeader(req.Header), "body", scrubBody(data))...)
+			} else {
+				logger.Debug("foreman request", append(fields, "headers", scrubHeader(req.Header))...)
+			}
+
+			start := time.Now()
+			resp, err := next.Do(req)
+			if err != nil {
+				logger.Error("foreman request failed", append(fields, "duration", time.Since(start), "error", err)...)
+				return nil, err
+			}
+
+			respBody, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+			requestID := resp.Header.Get(requestIDHeader)
+			if requestID == "" {
+				requestID = req.Header.Get(requestIDHeader)
+			}
+			fields = append(fields, "status", resp.StatusCode, "duration", time.Since(start), "request_id", requestID)
+			if err != nil {
+				logger.Error("foreman response failed", append(fields, "error", err)...)
+				return nil, err
+			}
+
+			// client errors are returned to the caller, server errors are worth a warning
+			if resp.StatusCode >= 500 {
+				logger.Warn("foreman response", append(fields, "body", scrubBody(respBody))...)
+			} else {
+				logger.Debug("foreman response", fields...)
+			}
+
+			return resp, nil
+		})
+	}
+}
+
+// measure reports the status and duration of every request to the metrics of the connection
+func (ci *ConnectionInfo) measure(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+
+		if ci.Metrics == nil {
+			return next.Do(req)
+		}
+
+		start := time.Now()
+		resp, err := next.Do(req)
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		ci.Metrics.ObserveRequest(req.Method, apiEndpoint(req.URL.Path), status, time.Since(start), err)
+
+		return resp, err
+	})
+}
+
+// limit waits for the rate limiter of the connection, the in flight slot is released once the response body is closed
+func (ci *ConnectionInfo) limit(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+
+		release, err := ci.RateLimiter.wait(req.Context(), req.Method, apiEndpoint(req.URL.Path))
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := next.Do(req)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		resp.Body = onClose(resp.Body, release)
+
+		return resp, nil
+	})
+}
+
+// doer returns the chain every request of the connection is sent through, from the outside in:
+// the connection middlewares in order, logging, metrics, rate limiting and finally the http client
+func (ci *ConnectionInfo) doer() Doer {
+
+	var client Doer = http.DefaultClient
+	if ci.Client != nil {
+		client = ci.Client
+	}
+
+	middlewares := append([]Middleware{}, ci.Middleware...)
+	if ci.Logger != nil {
+		middlewares = append(middlewares, Logging(ci.Logger))
+	}
+	middlewares = append(middlewares, ci.measure, ci.limit)
+
+	return Chain(middlewares...)(client)
+}
+
+// requestBody returns a copy of the request body without consuming it
+func requestBody(req *http.Request) []byte {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+	data, _ := ioutil.ReadAll(body)
+	return data
+}
+
+// closeFuncBody calls fn once when the body is closed
+type closeFuncBody struct {
+	io.ReadCloser
+	once sync.Once
+	fn   func()
+}
+
+// Close implements io.Closer
+func (b *closeFuncBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.fn)
+	return err
+}
+
+// onClose returns the body calling fn once it is closed
+func onClose(body io.ReadCloser, fn func()) io.ReadCloser {
+	return &closeFuncBody{ReadCloser: body, fn: fn}
+}
@@ -0,0 +1,227 @@
+package foreman_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	middlewareTimeout = 180
+)
+
+// trace returns a middleware printing when the request enters and the response leaves it
+func trace(name string) foreman.Middleware {
+	return func(next foreman.Doer) foreman.Doer {
+		return foreman.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			fmt.Println(name, "request")
+			resp, err := next.Do(req)
+			fmt.Println(name, "response")
+			return resp, err
+		})
+	}
+}
+
+func ExampleChain() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Println("foreman", req.Header.Get("User-Agent"))
+		check(rw.Write([]byte(`{"result":"ok"}`)))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), middlewareTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{BaseURL: server.URL, Client: server.Client()}
+	api.Middleware = []foreman.Middleware{trace("outer"), foreman.UserAgent("provisioner/1.0"), trace("inner")}
+
+	_, _, err := api.CheckStatus(ctx)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// Output: outer request
+	// inner request
+	// foreman provisioner/1.0
+	// inner response
+	// outer response
+}
+
+func TestMiddlewareRequestID(t *testing.T) {
+
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ids = append(ids, req.Header.Get("X-Request-Id"))
+		check(rw.Write([]byte(`{}`)))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	api := foreman.ConnectionInfo{
+		BaseURL:    server.URL,
+		Client:     server.Client(),
+		Middleware: []foreman.Middleware{foreman.RequestID()},
+		Logger:     foreman.NewStdLogger(log.New(&buf, "", 0), foreman.LevelDebug),
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := api.GetSetting(context.Background(), "token_duration")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(ids) != 2 || len(ids[0]) != 32 || ids[0] == ids[1] {
+		t.Fatalf("expected two distinct request ids got %q", ids)
+	}
+	if !strings.Contains(buf.String(), "request_id="+ids[0]) {
+		t.Errorf("expected the request id to be logged got %s", buf.String())
+	}
+}
+
+func TestMiddlewareRetry(t *testing.T) {
+
+	tt := []struct {
+		name     string
+		methods  []string
+		statuses []int
+		call     func(api *foreman.ConnectionInfo) error
+		requests int
+		err      bool
+	}{
+		{
+			name:     "Retried until success",
+			statuses: []int{503, 502, 200},
+			call: func(api *foreman.ConnectionInfo) error {
+				_, err := api.GetSetting(context.Background(), "token_duration")
+				return err
+			},
+			requests: 3,
+		},
+		{
+			name:     "Put not retried by default",
+			statuses: []int{503, 200},
+			call: func(api *foreman.ConnectionInfo) error {
+				_, err := api.UpdateSetting(context.Background(), "token_duration", 60)
+				return err
+			},
+			requests: 1,
+			err:      true,
+		},
+		{
+			name:     "Body resent",
+			methods:  []string{http.MethodGet, http.MethodPut},
+			statuses: []int{429, 200},
+			call: func(api *foreman.ConnectionInfo) error {
+				_, err := api.UpdateSetting(context.Background(), "token_duration", 60)
+				return err
+			},
+			requests: 2,
+		},
+		{
+			name:     "Attempts exhausted",
+			statuses: []int{503, 503, 503, 200},
+			call: func(api *foreman.ConnectionInfo) error {
+				_, err := api.GetSetting(context.Background(), "token_duration")
+				return err
+			},
+			requests: 3,
+			err:      true,
+		},
+		{
+			name:     "Post not retried",
+			statuses: []int{503, 200},
+			call: func(api *foreman.ConnectionInfo) error {
+				_, err := api.CreateUser(context.Background(), foreman.User{Login: "jdoe"})
+				return err
+			},
+			requests: 1,
+			err:      true,
+		},
+		{
+			name:     "Client error not retried",
+			statuses: []int{404, 200},
+			call: func(api *foreman.ConnectionInfo) error {
+				_, err := api.GetSetting(context.Background(), "token_duration")
+				return err
+			},
+			requests: 1,
+			err:      true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+
+			var mu sync.Mutex
+			var bodies []string
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				mu.Lock()
+				body, _ := ioutil.ReadAll(req.Body)
+				bodies = append(bodies, string(body))
+				status := tc.statuses[len(bodies)-1]
+				mu.Unlock()
+
+				rw.Header().Set("Retry-After", "0")
+				rw.WriteHeader(status)
+				check(rw.Write([]byte(`{}`)))
+			}))
+			defer server.Close()
+
+			api := foreman.ConnectionInfo{
+				BaseURL:    server.URL,
+				Client:     server.Client(),
+				Middleware: []foreman.Middleware{foreman.Retry(3, time.Hour, tc.methods...)},
+			}
+
+			err := tc.call(&api)
+			if (err != nil) != tc.err {
+				t.Errorf("expected error %t got %v", tc.err, err)
+			}
+			if len(bodies) != tc.requests {
+				t.Fatalf("expected %d requests got %d", tc.requests, len(bodies))
+			}
+			for _, body := range bodies {
+				if body != bodies[0] {
+					t.Errorf("expected the same body on every attempt got %q", bodies)
+				}
+			}
+		})
+	}
+}
+
+func TestMiddlewareTimeout(t *testing.T) {
+
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		select {
+		case <-done:
+		case <-req.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	api := foreman.ConnectionInfo{
+		BaseURL:    server.URL,
+		Client:     server.Client(),
+		Middleware: []foreman.Middleware{foreman.Timeout(20 * time.Millisecond)},
+	}
+
+	_, err := api.ListSettings(context.Background(), "")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded got %v", err)
+	}
+}
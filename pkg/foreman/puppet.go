@@ -0,0 +1,274 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+const (
+	puppetclassesapi = "api/puppetclasses"
+	environmentsapi  = "api/environments"
+	hostgroupsapi    = "api/hostgroups"
+	smartproxiesapi  = "api/smart_proxies"
+	smartparamsapi   = "api/smart_class_parameters"
+)
+
+// PuppetClass represents a puppet class known to foreman
+type PuppetClass struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Module string `json:"module_name"`
+}
+
+// Environment represents a puppet environment
+type Environment struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// SmartClassParameter represents a puppet class parameter that can be overridden by matchers
+type SmartClassParameter struct {
+	ID                  int         `json:"id,omitempty"`
+	Parameter           string      `json:"parameter,omitempty"`
+	ParameterType       string      `json:"parameter_type,omitempty"`
+	Description         string      `json:"description,omitempty"`
+	Override            *bool       `json:"override,omitempty"`
+	DefaultValue        interface{} `json:"default_value,omitempty"`
+	OverrideValueOrder  string      `json:"override_value_order,omitempty"`
+	OverrideValuesCount int         `json:"override_values_count,omitempty"`
+	PuppetClass         *struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"puppetclass,omitempty"`
+}
+
+// OverrideValue is the value a smart class parameter takes for a matcher such as fqdn=host1 or hostgroup=web
+type OverrideValue struct {
+	ID    int         `json:"id,omitempty"`
+	Match string      `json:"match"`
+	Value interface{} `json:"value"`
+	Omit  *bool       `json:"omit,omitempty"`
+}
+
+// ENC contains the external node classifier data foreman provides to puppet for a host
+type ENC struct {
+	Classes     map[string]map[string]interface{} `json:"classes"`
+	Parameters  map[string]interface{}            `json:"parameters"`
+	Environment string                            `json:"environment"`
+}
+
+// EnvironmentsResource returns the typed resource for puppet environments
+func (ci *ConnectionInfo) EnvironmentsResource() *Resource[Environment] {
+	return NewResource[Environment](ci, "environment", environmentsapi)
+}
+
+// SmartClassParametersResource returns the typed resource for smart class parameters
+func (ci *ConnectionInfo) SmartClassParametersResource() *Resource[SmartClassParameter] {
+	return NewResource[SmartClassParameter](ci, "smart_class_parameter", smartparamsapi)
+}
+
+// ListPuppetClasses returns the puppet classes matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListPuppetClasses(ctx context.Context, search string) ([]PuppetClass, error) {
+
+	var classes []PuppetClass
+
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("per_page", strconv.Itoa(perPage))
+		if search != "" {
+			query.Set("search", search)
+		}
+
+		var resp listResp
+		err := ci.doJSON(ctx, http.MethodGet, ci.apiURL(query, puppetclassesapi), nil, &resp)
+		if err != nil {
+			return nil, err
+		}
+
+		// results are grouped by module name
+		var modules map[string][]PuppetClass
+		if len(resp.Results) > 0 {
+			err = json.Unmarshal(resp.Results, &modules)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		names := make([]string, 0, len(modules))
+		for module := range modules {
+			names = append(names, module)
+		}
+		sort.Strings(names)
+
+		pageCount := 0
+		for _, module := range names {
+			for _, class := range modules[module] {
+				class.Module = module
+				classes = append(classes, class)
+				pageCount++
+			}
+		}
+
+		if pageCount == 0 || len(classes) >= resp.Subtotal {
+			break
+		}
+	}
+
+	return classes, nil
+}
+
+// ResolvePuppetClass returns the id of the puppet class with the name provided
+func (ci *ConnectionInfo) ResolvePuppetClass(ctx context.Context, name string) (int, error) {
+
+	if id, err := strconv.Atoi(name); err == nil {
+		return id, nil
+	}
+
+	classes, err := ci.ListPuppetClasses(ctx, fmt.Sprintf("name = %q", name))
+	if err != nil {
+		return 0, err
+	}
+	for _, c := range classes {
+		if c.Name == name {
+			return c.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("puppetclass [%s] %w", name, ErrNotFound)
+}
+
+// ListEnvironments returns the puppet environments matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListEnvironments(ctx context.Context, search string) ([]Environment, error) {
+	return ci.EnvironmentsResource().List(ctx, search)
+}
+
+// ResolveEnvironment returns the id of the puppet environment with the name provided
+func (ci *ConnectionInfo) ResolveEnvironment(ctx context.Context, name string) (int, error) {
+	return ci.EnvironmentsResource().Resolve(ctx, name)
+}
+
+// AddHostPuppetClass assigns the puppet class to the host
+func (ci *ConnectionInfo) AddHostPuppetClass(ctx context.Context, classID int) error {
+	req := map[string]int{"puppetclass_id": classID}
+	return ci.doJSON(ctx, http.MethodPost, ci.apiURL(nil, hostsapi, ci.Hostname, "puppetclass_ids"), req, nil)
+}
+
+// RemoveHostPuppetClass removes the puppet class from the host
+func (ci *ConnectionInfo) RemoveHostPuppetClass(ctx context.Context, classID int) error {
+	return ci.doJSON(ctx, http.MethodDelete, ci.apiURL(nil, hostsapi, ci.Hostname, "puppetclass_ids", strconv.Itoa(classID)), nil, nil)
+}
+
+// AddHostgroupPuppetClass assigns the puppet class to the hostgroup
+func (ci *ConnectionInfo) AddHostgroupPuppetClass(ctx context.Context, hostgroupID int, classID int) error {
+	req := map[string]int{"puppetclass_id": classID}
+	return ci.doJSON(ctx, http.MethodPost, ci.apiURL(nil, hostgroupsapi, strconv.Itoa(hostgroupID), "puppetclass_ids"), req, nil)
+}
+
+// RemoveHostgroupPuppetClass removes the puppet class from the hostgroup
+func (ci *ConnectionInfo) RemoveHostgroupPuppetClass(ctx context.Context, hostgroupID int, classID int) error {
+	return ci.doJSON(ctx, http.MethodDelete, ci.apiURL(nil, hostgroupsapi, strconv.Itoa(hostgroupID), "puppetclass_ids", strconv.Itoa(classID)), nil, nil)
+}
+
+// ImportPuppetClasses imports the puppet classes of every environment, or only environmentID when not 0, from the smart proxy.
+// A dry run returns the changes without applying them
+func (ci *ConnectionInfo) ImportPuppetClasses(ctx context.Context, proxyID int, environmentID int, dryrun bool) (map[string]interface{}, error) {
+
+	elem := []string{smartproxiesapi, strconv.Itoa(proxyID)}
+	if environmentID != 0 {
+		elem = append(elem, "environments", strconv.Itoa(environmentID))
+	}
+	elem = append(elem, "import_puppetclasses")
+
+	var query url.Values
+	if dryrun {
+		query = url.Values{"dryrun": {"true"}}
+	}
+
+	var result map[string]interface{}
+	err := ci.doJSON(ctx, http.MethodPost, ci.apiURL(query, elem...), nil, &result)
+
+	return result, err
+}
+
+// ListSmartClassParameters returns the smart class parameters matching the search, e.g. puppetclass = apache
+func (ci *ConnectionInfo) ListSmartClassParameters(ctx context.Context, search string) ([]SmartClassParameter, error) {
+	return ci.SmartClassParametersResource().List(ctx, search)
+}
+
+// GetSmartClassParameter returns the smart class parameter with the id provided
+func (ci *ConnectionInfo) GetSmartClassParameter(ctx context.Context, id int) (*SmartClassParameter, error) {
+	return ci.SmartClassParametersResource().Get(ctx, strconv.Itoa(id))
+}
+
+// UpdateSmartClassParameter updates the smart class parameter with the id provided, set Override to allow override values
+func (ci *ConnectionInfo) UpdateSmartClassParameter(ctx context.Context, id int, param SmartClassParameter) (*SmartClassParameter, error) {
+	return ci.SmartClassParametersResource().Update(ctx, strconv.Itoa(id), param)
+}
+
+// ResolveSmartClassParameter returns the id of the parameter of the puppet class provided
+func (ci *ConnectionInfo) ResolveSmartClassParameter(ctx context.Context, class string, parameter string) (int, error) {
+
+	params, err := ci.ListSmartClassParameters(ctx, fmt.Sprintf("puppetclass = %q and key = %q", class, parameter))
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range params {
+		if p.Parameter == parameter {
+			return p.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("smart class parameter [%s::%s] %w", class, parameter, ErrNotFound)
+}
+
+// ListOverrideValues returns the override values of the smart class parameter
+func (ci *ConnectionInfo) ListOverrideValues(ctx context.Context, paramID int) ([]OverrideValue, error) {
+	var values []OverrideValue
+	err := ci.list(ctx, "", &values, smartparamsapi, strconv.Itoa(paramID), "override_values")
+	return values, err
+}
+
+// SetOverrideValue creates or updates the override value of the smart class parameter for the matcher provided
+func (ci *ConnectionInfo) SetOverrideValue(ctx context.Context, paramID int, match string, value interface{}) (*OverrideValue, error) {
+
+	values, err := ci.ListOverrideValues(ctx, paramID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := map[string]OverrideValue{"override_value": {Match: match, Value: value}}
+
+	var saved OverrideValue
+	for _, v := range values {
+		if v.Match == match {
+			err = ci.doJSON(ctx, http.MethodPut, ci.apiURL(nil, smartparamsapi, strconv.Itoa(paramID), "override_values", strconv.Itoa(v.ID)), req, &saved)
+			return &saved, err
+		}
+	}
+
+	err = ci.doJSON(ctx, http.MethodPost, ci.apiURL(nil, smartparamsapi, strconv.Itoa(paramID), "override_values"), req, &saved)
+
+	return &saved, err
+}
+
+// DeleteOverrideValue deletes the override value of the smart class parameter
+func (ci *ConnectionInfo) DeleteOverrideValue(ctx context.Context, paramID int, id int) error {
+	return ci.doJSON(ctx, http.MethodDelete, ci.apiURL(nil, smartparamsapi, strconv.Itoa(paramID), "override_values", strconv.Itoa(id)), nil, nil)
+}
+
+// GetHostENC returns the effective external node classifier data of the host
+func (ci *ConnectionInfo) GetHostENC(ctx context.Context) (*ENC, error) {
+
+	var resp struct {
+		Data ENC `json:"data"`
+	}
+	err := ci.doJSON(ctx, http.MethodGet, ci.apiURL(nil, hostsapi, ci.Hostname, "enc"), nil, &resp)
+
+	return &resp.Data, err
+}
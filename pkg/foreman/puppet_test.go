@@ -0,0 +1,101 @@
+package foreman_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	puppetTimeout = 180
+)
+
+func ExampleConnectionInfo_ListPuppetClasses() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(`{"total":3,"subtotal":3,"page":1,"per_page":100,"results":{"ntp":[{"id":3,"name":"ntp"}],"apache":[{"id":1,"name":"apache"},{"id":2,"name":"apache::mod"}]}}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, puppetTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	classes, err := api.ListPuppetClasses(ctx, "")
+
+	for _, c := range classes {
+		fmt.Printf("%s/%s ", c.Module, c.Name)
+	}
+	fmt.Printf("%v", err)
+
+	// Output: apache/apache apache/apache::mod ntp/ntp <nil>
+}
+
+func ExampleConnectionInfo_GetHostENC() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(`{"data":{"classes":{"apache":{"port":8080}},"parameters":{"foreman_env":"production"},"environment":"production"}}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, puppetTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client(), Hostname: "test"}
+	enc, err := api.GetHostENC(ctx)
+
+	fmt.Printf("%s %v %v", enc.Environment, enc.Classes["apache"]["port"], err)
+
+	// Output: production 8080 <nil>
+}
+
+func TestSetOverrideValue(t *testing.T) {
+
+	tt := []struct {
+		name           string
+		existing       string
+		expectedresult string
+	}{
+		{name: "create", existing: `[]`, expectedresult: "POST /api/smart_class_parameters/5/override_values"},
+		{name: "update", existing: `[{"id":9,"match":"fqdn=test","value":80}]`, expectedresult: "PUT /api/smart_class_parameters/5/override_values/9"},
+	}
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, puppetTimeout*time.Second)
+	defer cancel()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var request, body string
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if req.Method == http.MethodGet {
+					check(rw.Write([]byte(`{"total":1,"subtotal":1,"page":1,"per_page":100,"results":` + tc.existing + `}`)))
+					return
+				}
+				data, _ := ioutil.ReadAll(req.Body)
+				request, body = req.Method+" "+req.URL.Path, string(data)
+				check(rw.Write([]byte(`{"id":9,"match":"fqdn=test","value":"8080"}`)))
+			}))
+			defer server.Close()
+
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+			value, err := api.SetOverrideValue(ctx, 5, "fqdn=test", "8080")
+			if err != nil {
+				t.Fatalf("Could not read response %v correctly", err)
+			}
+			if tc.expectedresult != request || body != `{"override_value":{"match":"fqdn=test","value":"8080"}}` {
+				t.Errorf("Test %v result should be %v, got  `%v %v`", tc.name, tc.expectedresult, request, body)
+			}
+			log.Printf("Response: %v", value)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package foreman
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit configures a token bucket of Rate requests per second refilled up to Burst,
+// and a cap of MaxInFlight concurrent requests. Zero values are unlimited
+type Limit struct {
+	Rate        float64
+	Burst       int
+	MaxInFlight int
+}
+
+// RateLimiter limits the rate and concurrency of api calls. It is safe to share between goroutines
+// and connections so every caller of a foreman instance draws from the same budget.
+// The zero value applies no global limit, endpoint limits can still be added to it
+type RateLimiter struct {
+	mu        sync.Mutex
+	global    *bucket
+	endpoints map[string]*bucket
+}
+
+// bucket is the state of a single limit
+type bucket struct {
+	limit    Limit
+	tokens   float64
+	last     time.Time
+	inflight chan struct{}
+}
+
+// NewRateLimiter returns a limiter applying the limit to every request
+func NewRateLimiter(limit Limit) *RateLimiter {
+	return &RateLimiter{global: newBucket(limit), endpoints: map[string]*bucket{}}
+}
+
+// SetEndpointLimit adds a limit for the method and endpoint, e.g. POST /api/hosts, endpoints use :id in place of ids.
+// Requests to the endpoint must satisfy both this limit and the one of the limiter
+func (rl *RateLimiter) SetEndpointLimit(method string, endpoint string, limit Limit) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if rl.endpoints == nil {
+		rl.endpoints = map[string]*bucket{}
+	}
+	rl.endpoints[method+" "+endpoint] = newBucket(limit)
+}
+
+// wait blocks until the request is allowed or the context is done, release must be called once the request completes
+func (rl *RateLimiter) wait(ctx context.Context, method string, endpoint string) (func(), error) {
+
+	if rl == nil {
+		return func() {}, nil
+	}
+
+	rl.mu.Lock()
+	if rl.global == nil {
+		rl.global = newBucket(Limit{})
+	}
+	global, limited := rl.global, rl.endpoints[method+" "+endpoint]
+	rl.mu.Unlock()
+
+	// the endpoint slot and every rate token are taken before the global slot,
+	// so a request queued or sleeping on its endpoint does not hold a global slot other endpoints need
+	buckets := []*bucket{global}
+	release := func() {}
+	if limited != nil {
+		buckets = []*bucket{limited, global}
+		r, err := limited.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		release = r
+	}
+
+	// the tokens taken are returned when the request is never sent
+	refund := func(taken []*bucket) {
+		for _, b := range taken {
+			rl.refund(b)
+		}
+	}
+	for i, b := range buckets {
+		if err := rl.take(ctx, b); err != nil {
+			refund(buckets[:i])
+			release()
+			return nil, err
+		}
+	}
+
+	releaseGlobal, err := global.acquire(ctx)
+	if err != nil {
+		refund(buckets)
+		release()
+		return nil, err
+	}
+
+	return func() {
+		releaseGlobal()
+		release()
+	}, nil
+}
+
+// take waits for a token of the bucket, a token reserved by a cancelled request is returned
+func (rl *RateLimiter) take(ctx context.Context, b *bucket) error {
+
+	if b.limit.Rate <= 0 {
+		return nil
+	}
+
+	rl.mu.Lock()
+	now := time.Now()
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate)
+	b.last = now
+	b.tokens--
+	delay := time.Duration(-b.tokens / b.limit.Rate * float64(time.Second))
+	rl.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		rl.refund(b)
+		return ctx.Err()
+	}
+}
+
+// refund returns a token to the bucket
+func (rl *RateLimiter) refund(b *bucket) {
+
+	if b.limit.Rate <= 0 {
+		return
+	}
+
+	rl.mu.Lock()
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+1)
+	rl.mu.Unlock()
+}
+
+// newBucket returns a full bucket for the limit
+func newBucket(limit Limit) *bucket {
+
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	b := &bucket{limit: limit, tokens: float64(limit.Burst), last: time.Now()}
+	if limit.MaxInFlight > 0 {
+		b.inflight = make(chan struct{}, limit.MaxInFlight)
+	}
+
+	return b
+}
+
+// acquire waits for an in flight slot of the bucket and returns the function releasing it
+func (b *bucket) acquire(ctx context.Context) (func(), error) {
+
+	if b.inflight == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case b.inflight <- struct{}{}:
+		return func() { <-b.inflight }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
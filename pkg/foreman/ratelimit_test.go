@@ -0,0 +1,295 @@
+package foreman_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	ratelimitTimeout = 180
+)
+
+// concurrencyServer returns a server that records the highest number of requests in flight per method
+func concurrencyServer(delay time.Duration) (*httptest.Server, func(method string) int) {
+
+	var mu sync.Mutex
+	current := map[string]int{}
+	highest := map[string]int{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		current[req.Method]++
+		if current[req.Method] > highest[req.Method] {
+			highest[req.Method] = current[req.Method]
+		}
+		mu.Unlock()
+
+		time.Sleep(delay)
+
+		mu.Lock()
+		current[req.Method]--
+		mu.Unlock()
+
+		check(rw.Write([]byte(`{"id":1,"total":0,"subtotal":0,"results":[]}`)))
+	}))
+
+	return server, func(method string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return highest[method]
+	}
+}
+
+func ExampleRateLimiter() {
+
+	server, highest := concurrencyServer(20 * time.Millisecond)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), ratelimitTimeout*time.Second)
+	defer cancel()
+
+	limiter := foreman.NewRateLimiter(foreman.Limit{MaxInFlight: 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			api := foreman.ConnectionInfo{BaseURL: server.URL, Client: server.Client(), RateLimiter: limiter}
+			_, err := api.ListSettings(ctx, "")
+			if err != nil {
+				fmt.Println(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	fmt.Println(highest(http.MethodGet))
+
+	// Output: 2
+}
+
+func TestRateLimiterRate(t *testing.T) {
+
+	server, _ := concurrencyServer(0)
+	defer server.Close()
+
+	api := foreman.ConnectionInfo{BaseURL: server.URL, Client: server.Client(), RateLimiter: foreman.NewRateLimiter(foreman.Limit{Rate: 50, Burst: 2})}
+
+	start := time.Now()
+	for i := 0; i < 7; i++ {
+		_, err := api.GetSetting(context.Background(), "token_duration")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the burst of 2 is immediate, the other 5 requests wait 20ms each
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expected requests to be limited to 50/s took %s", elapsed)
+	}
+}
+
+func TestRateLimiterEndpoint(t *testing.T) {
+
+	server, highest := concurrencyServer(20 * time.Millisecond)
+	defer server.Close()
+
+	limiter := foreman.NewRateLimiter(foreman.Limit{})
+	limiter.SetEndpointLimit(http.MethodPost, "/api/users", foreman.Limit{MaxInFlight: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), ratelimitTimeout*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			api := foreman.ConnectionInfo{BaseURL: server.URL, Client: server.Client(), RateLimiter: limiter}
+			_, err := api.CreateUser(ctx, foreman.User{Login: "jdoe"})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			api := foreman.ConnectionInfo{BaseURL: server.URL, Client: server.Client(), RateLimiter: limiter}
+			_, err := api.GetUser(ctx, 1)
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := highest(http.MethodPost); got != 1 {
+		t.Errorf("expected 1 user creation in flight got %d", got)
+	}
+	if got := highest(http.MethodGet); got < 2 {
+		t.Errorf("expected reads not to be limited got %d in flight", got)
+	}
+}
+
+func TestRateLimiterContext(t *testing.T) {
+
+	server, _ := concurrencyServer(0)
+	defer server.Close()
+
+	tt := []struct {
+		name    string
+		limit   foreman.Limit
+		blocked func(api *foreman.ConnectionInfo) (func(), error)
+	}{
+		{
+			name:  "Waiting for a token",
+			limit: foreman.Limit{Rate: 0.01},
+			blocked: func(api *foreman.ConnectionInfo) (func(), error) {
+				_, err := api.GetSetting(context.Background(), "token_duration")
+				return func() {}, err
+			},
+		},
+		{
+			name:  "Waiting for a slot",
+			limit: foreman.Limit{MaxInFlight: 1},
+			blocked: func(api *foreman.ConnectionInfo) (func(), error) {
+				done := make(chan struct{})
+				started := make(chan struct{})
+				slow := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+					close(started)
+					<-done
+				}))
+				holder := *api
+				holder.BaseURL = slow.URL
+				go func() { _, _ = holder.GetSetting(context.Background(), "token_duration") }()
+				<-started
+				return func() { close(done); slow.Close() }, nil
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+
+			api := foreman.ConnectionInfo{BaseURL: server.URL, Client: server.Client(), RateLimiter: foreman.NewRateLimiter(tc.limit)}
+
+			cleanup, err := tc.blocked(&api)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer cleanup()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			_, err = api.GetSetting(ctx, "token_duration")
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("expected deadline exceeded got %v", err)
+			}
+		})
+	}
+}
+
+func TestRateLimiterZeroValue(t *testing.T) {
+
+	server, highest := concurrencyServer(20 * time.Millisecond)
+	defer server.Close()
+
+	limiter := &foreman.RateLimiter{}
+	limiter.SetEndpointLimit(http.MethodPost, "/api/users", foreman.Limit{MaxInFlight: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), ratelimitTimeout*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			api := foreman.ConnectionInfo{BaseURL: server.URL, Client: server.Client(), RateLimiter: limiter}
+			_, err := api.CreateUser(ctx, foreman.User{Login: "jdoe"})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := highest(http.MethodPost); got != 1 {
+		t.Errorf("expected 1 user creation in flight got %d", got)
+	}
+}
+
+func TestRateLimiterRefund(t *testing.T) {
+
+	server, _ := concurrencyServer(0)
+	defer server.Close()
+
+	limiter := foreman.NewRateLimiter(foreman.Limit{Rate: 10, Burst: 1})
+	limiter.SetEndpointLimit(http.MethodGet, "/api/settings/:id", foreman.Limit{Rate: 0.01, Burst: 1})
+
+	api := foreman.ConnectionInfo{BaseURL: server.URL, Client: server.Client(), RateLimiter: limiter}
+
+	// the global token is spent so the next settings request gives up waiting for it
+	_, err := api.GetUser(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = api.GetSetting(ctx, "token_duration")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded got %v", err)
+	}
+
+	// the endpoint token of the cancelled request was returned, only the global bucket is waited for
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = api.GetSetting(ctx, "token_duration")
+	if err != nil {
+		t.Errorf("expected the endpoint token to be refunded got %v", err)
+	}
+}
+
+func TestRateLimiterSlowEndpoint(t *testing.T) {
+
+	server, _ := concurrencyServer(0)
+	defer server.Close()
+
+	limiter := foreman.NewRateLimiter(foreman.Limit{MaxInFlight: 1})
+	limiter.SetEndpointLimit(http.MethodPost, "/api/hosts", foreman.Limit{Rate: 0.5, Burst: 1})
+
+	api := foreman.ConnectionInfo{BaseURL: server.URL, Client: server.Client(), RateLimiter: limiter}
+
+	// the second host creation sleeps about 2s for a token of its endpoint
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = api.HostsResource().Create(ctx, foreman.Host{Name: "dev99"})
+		}()
+	}
+	defer wg.Wait()
+	defer cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	start := time.Now()
+	_, err := api.GetUser(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected other endpoints not to wait for the host creation got %v", elapsed)
+	}
+}
@@ -0,0 +1,217 @@
+package foreman
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	// RecordMode sends requests to foreman and records the interactions
+	RecordMode RecorderMode = iota
+	// ReplayMode answers requests from the cassette without using the network
+	ReplayMode
+)
+
+// RecorderMode controls whether a Recorder records or replays interactions
+type RecorderMode int
+
+// Cassette contains the interactions recorded from a foreman instance
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response foreman returned
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest contains the parts of a request used to match it on replay
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse contains the response returned for a recorded request
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Recorder is an http.RoundTripper that records interactions to a cassette file or replays them from it.
+// Requests are matched on method, path, query and body, each recorded interaction is replayed once
+type Recorder struct {
+	Mode      RecorderMode
+	Path      string
+	Transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	replayed []bool
+}
+
+// NewRecorder returns a recorder for the cassette file provided, in replay mode the cassette must exist.
+// A nil transport uses http.DefaultTransport when recording
+func NewRecorder(path string, mode RecorderMode, transport http.RoundTripper) (*Recorder, error) {
+
+	r := &Recorder{Mode: mode, Path: path, Transport: transport}
+
+	if mode == ReplayMode {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(data, &r.cassette)
+		if err != nil {
+			return nil, fmt.Errorf("cassette [%s]: %v", path, err)
+		}
+		r.replayed = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Client returns an http client that sends its requests through the recorder
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		// a RoundTripper must not modify the request it is given, the body is read again from a clone
+		req = req.Clone(req.Context())
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  scrubQuery(req.URL.Query()),
+		Body:   scrubBody(body),
+	}
+
+	if r.Mode == ReplayMode {
+		return r.replay(req, recorded)
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	header := resp.Header.Clone()
+	for _, h := range scrubbedHeaders {
+		header.Del(h)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  recorded,
+		Response: RecordedResponse{StatusCode: resp.StatusCode, Header: header, Body: scrubBody(respBody)},
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the cassette file
+func (r *Recorder) Save() error {
+
+	if r.Mode != RecordMode {
+		return errors.New("only a recorder in record mode can be saved")
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(r.Path, append(data, '\n'), os.FileMode(0644))
+}
+
+// replay returns the response of the first unused interaction matching the request
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, in := range r.cassette.Interactions {
+		if r.replayed[i] || !in.Request.matches(recorded) {
+			continue
+		}
+		r.replayed[i] = true
+
+		header := in.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cassette [%s] has no interaction for %s %s", r.Path, req.Method, req.URL.RequestURI())
+}
+
+// matches reports whether both requests have the same method, path, query and body,
+// json bodies are compared by value so key order and whitespace are ignored
+func (rr RecordedRequest) matches(other RecordedRequest) bool {
+
+	if rr.Method != other.Method || rr.Path != other.Path || rr.Query != other.Query {
+		return false
+	}
+	if rr.Body == other.Body {
+		return true
+	}
+
+	var a, b interface{}
+	if json.Unmarshal([]byte(rr.Body), &a) != nil || json.Unmarshal([]byte(other.Body), &b) != nil {
+		return false
+	}
+	x, _ := json.Marshal(a)
+	y, _ := json.Marshal(b)
+
+	return bytes.Equal(x, y)
+}
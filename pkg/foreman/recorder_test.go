@@ -0,0 +1,222 @@
+package foreman_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	recorderTimeout = 180
+)
+
+func ExampleRecorder() {
+
+	recorder, err := foreman.NewRecorder(filepath.Join("testdata", "status.json"), foreman.ReplayMode, nil)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), recorderTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: "https://foreman.example.com", Client: recorder.Client()}
+
+	_, status, err := api.CheckStatus(ctx)
+	fmt.Println(status, err)
+
+	orgs, err := api.ListOrganizations(ctx, "")
+	fmt.Println(orgs[0].Name, err)
+
+	// Output: {"result":"ok","status":200,"version":"1.24.2","api_version":2} <nil>
+	// ACME <nil>
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		rw.Header().Set("Set-Cookie", "_session_id=abc")
+		check(rw.Write([]byte(`{"id":12,"login":"jdoe","password":"from-server"}`)))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "cassettes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cassette := filepath.Join(dir, "users.json")
+
+	ctx, cancel := context.WithTimeout(context.Background(), recorderTimeout*time.Second)
+	defer cancel()
+
+	user := foreman.User{Login: "jdoe", Mail: "jdoe@example.com", Password: "s3cret"}
+
+	recorder, err := foreman.NewRecorder(cassette, foreman.RecordMode, server.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := foreman.ConnectionInfo{Username: "admin", Password: "hunter2", BaseURL: server.URL, Client: recorder.Client()}
+	recordedUser, err := api.CreateUser(ctx, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = recorder.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"hunter2", "s3cret", "from-server", "_session_id", "Authorization"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q: %s", secret, data)
+		}
+	}
+
+	tt := []struct {
+		name    string
+		user    foreman.User
+		replays int
+		err     bool
+	}{
+		{name: "Replay matching body", user: user, replays: 1},
+		{name: "Replay used once", user: user, replays: 2, err: true},
+		{name: "Replay different body", user: foreman.User{Login: "other"}, replays: 1, err: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+
+			replayer, err := foreman.NewRecorder(cassette, foreman.ReplayMode, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			api := foreman.ConnectionInfo{Username: "admin", Password: "other", BaseURL: "http://replay.invalid", Client: replayer.Client()}
+
+			for i := 0; i < tc.replays; i++ {
+				var replayed *foreman.User
+				replayed, err = api.CreateUser(ctx, tc.user)
+				if err == nil && replayed.ID != recordedUser.ID {
+					t.Errorf("expected user %d got %d", recordedUser.ID, replayed.ID)
+				}
+			}
+			if (err != nil) != tc.err {
+				t.Errorf("expected error %t got %v", tc.err, err)
+			}
+		})
+	}
+
+	if calls != 1 {
+		t.Errorf("expected 1 call to the server got %d", calls)
+	}
+}
+
+func TestRecorderMatchesQuery(t *testing.T) {
+
+	cassette := foreman.Cassette{Interactions: []foreman.Interaction{
+		{
+			Request:  foreman.RecordedRequest{Method: http.MethodGet, Path: "/api/organizations", Query: "page=1&per_page=100&search=name+%3D+ACME"},
+			Response: foreman.RecordedResponse{StatusCode: http.StatusOK, Body: `{"subtotal":1,"results":[{"id":9,"name":"ACME"}]}`},
+		},
+	}}
+
+	dir, err := ioutil.TempDir("", "cassettes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "organizations.json")
+
+	data, err := json.Marshal(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(path, data, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tt := []struct {
+		name   string
+		search string
+		err    bool
+	}{
+		{name: "Same query", search: "name = ACME"},
+		{name: "Different query", search: "name = Other", err: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+
+			recorder, err := foreman.NewRecorder(path, foreman.ReplayMode, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			api := foreman.ConnectionInfo{BaseURL: "http://replay.invalid", Client: recorder.Client()}
+
+			orgs, err := api.ListOrganizations(context.Background(), tc.search)
+			if (err != nil) != tc.err {
+				t.Errorf("expected error %t got %v", tc.err, err)
+			}
+			if err == nil && (len(orgs) != 1 || orgs[0].ID != 9) {
+				t.Errorf("unexpected organizations %+v", orgs)
+			}
+		})
+	}
+}
+
+func TestRecorderKeepsRequest(t *testing.T) {
+
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		data, _ := ioutil.ReadAll(req.Body)
+		received = string(data)
+		check(rw.Write([]byte(`{}`)))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "cassettes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	recorder, err := foreman.NewRecorder(filepath.Join(dir, "body.json"), foreman.RecordMode, server.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := ioutil.NopCloser(strings.NewReader(`{"name":"dev99"}`))
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/hosts", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := recorder.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if req.Body != body {
+		t.Errorf("expected the body of the request to be left as is, got %v", req.Body)
+	}
+	if received != `{"name":"dev99"}` {
+		t.Errorf("expected the server to receive the body, got %q", received)
+	}
+}
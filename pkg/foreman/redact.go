@@ -0,0 +1,118 @@
+package foreman
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	scrubbed = "[FILTERED]"
+)
+
+// secretKeys are the json keys and query parameters whose values are never logged or recorded
+var secretKeys = []string{"password", "token", "secret", "api_key", "private_key"}
+
+// scrubbedHeaders are the headers never logged or recorded
+var scrubbedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// scrubHeader returns a copy of the header with the values of credential headers replaced
+func scrubHeader(header http.Header) http.Header {
+
+	h := header.Clone()
+	for _, name := range scrubbedHeaders {
+		if _, ok := h[http.CanonicalHeaderKey(name)]; ok {
+			h.Set(name, scrubbed)
+		}
+	}
+
+	return h
+}
+
+// scrubURL returns the url without user info and with secret query values replaced
+func scrubURL(u *url.URL) string {
+
+	c := *u
+	c.User = nil
+	c.RawQuery = scrubQuery(c.Query())
+
+	return c.String()
+}
+
+// scrubQuery returns the encoded query, sorted by key, with secret values replaced
+func scrubQuery(query url.Values) string {
+
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if isSecret(k) {
+			for i := range query[k] {
+				query[k][i] = scrubbed
+			}
+		}
+	}
+
+	return query.Encode()
+}
+
+// scrubBody returns the body with the values of secret json keys replaced, non json bodies are returned as is
+func scrubBody(body []byte) string {
+
+	var v interface{}
+	if len(body) == 0 || json.Unmarshal(body, &v) != nil {
+		return string(body)
+	}
+
+	data, err := json.Marshal(scrubValue(v))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(data)
+}
+
+// scrubValue walks a decoded json value replacing the values of secret keys and hidden parameters
+func scrubValue(v interface{}) interface{} {
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		// parameters marked as hidden keep their value out of logs and cassettes
+		if hidden, _ := t["hidden_value"].(bool); hidden {
+			if _, ok := t["value"]; ok {
+				t["value"] = scrubbed
+			}
+		}
+		for k, val := range t {
+			if isSecret(k) {
+				if _, ok := val.(string); ok {
+					t[k] = scrubbed
+					continue
+				}
+			}
+			t[k] = scrubValue(val)
+		}
+	case []interface{}:
+		for i := range t {
+			t[i] = scrubValue(t[i])
+		}
+	}
+
+	return v
+}
+
+// isSecret reports whether the key holds a credential
+func isSecret(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range secretKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
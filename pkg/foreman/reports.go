@@ -0,0 +1,118 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+const (
+	configreportsapi = "api/config_reports"
+	reportTimeLayout = "2006-01-02 15:04:05 MST"
+)
+
+// ConfigReport represents a configuration management (e.g. puppet) run report sent to foreman
+type ConfigReport struct {
+	ID         int                           `json:"id"`
+	HostID     int                           `json:"host_id"`
+	HostName   string                        `json:"host_name"`
+	ReportedAt string                        `json:"reported_at"`
+	Origin     string                        `json:"origin"`
+	Status     ReportStatus                  `json:"status"`
+	Metrics    map[string]map[string]float64 `json:"metrics"`
+	Logs       []ReportLog                   `json:"logs"`
+}
+
+// ReportStatus contains the resource status counts of a config report
+type ReportStatus struct {
+	Applied        int `json:"applied"`
+	Restarted      int `json:"restarted"`
+	Failed         int `json:"failed"`
+	FailedRestarts int `json:"failed_restarts"`
+	Skipped        int `json:"skipped"`
+	Pending        int `json:"pending"`
+}
+
+// ReportLog is a single log line of a config report
+type ReportLog struct {
+	Level   string
+	Source  string
+	Message string
+}
+
+// reportLogResp contains the nested structure foreman uses for report log lines
+type reportLogResp struct {
+	Log struct {
+		Level   string `json:"level"`
+		Sources struct {
+			Source string `json:"source"`
+		} `json:"sources"`
+		Messages struct {
+			Message string `json:"message"`
+		} `json:"messages"`
+	} `json:"log"`
+}
+
+// UnmarshalJSON flattens the nested log line returned by foreman
+func (l *ReportLog) UnmarshalJSON(data []byte) error {
+
+	var resp reportLogResp
+	err := json.Unmarshal(data, &resp)
+	if err != nil {
+		return err
+	}
+
+	l.Level = resp.Log.Level
+	l.Source = resp.Log.Sources.Source
+	l.Message = resp.Log.Messages.Message
+
+	return nil
+}
+
+// Failures returns the number of failed resources and failed restarts in the report
+func (r *ConfigReport) Failures() int {
+	return r.Status.Failed + r.Status.FailedRestarts
+}
+
+// ReportedTime returns the time the report was sent
+func (r *ConfigReport) ReportedTime() (time.Time, error) {
+
+	t, err := time.Parse(reportTimeLayout, r.ReportedAt)
+	if err != nil {
+		return time.Parse(time.RFC3339, r.ReportedAt)
+	}
+
+	return t, nil
+}
+
+// OutOfSync reports whether the report is older than the interval provided
+func (r *ConfigReport) OutOfSync(interval time.Duration) (bool, error) {
+
+	t, err := r.ReportedTime()
+	if err != nil {
+		return false, err
+	}
+
+	return time.Since(t) > interval, nil
+}
+
+// ConfigReportsResource returns the typed read only resource for config reports, they are uploaded by the config management agents
+func (ci *ConnectionInfo) ConfigReportsResource() *ReadOnlyResource[ConfigReport] {
+	return NewReadOnlyResource[ConfigReport](ci, configreportsapi)
+}
+
+// ListConfigReports returns the config reports matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListConfigReports(ctx context.Context, search string) ([]ConfigReport, error) {
+	return ci.ConfigReportsResource().List(ctx, search)
+}
+
+// GetConfigReport returns the config report, including its logs, with the id provided
+func (ci *ConnectionInfo) GetConfigReport(ctx context.Context, id int) (*ConfigReport, error) {
+	return ci.ConfigReportsResource().Get(ctx, strconv.Itoa(id))
+}
+
+// GetLastConfigReport returns the most recent config report of the host
+func (ci *ConnectionInfo) GetLastConfigReport(ctx context.Context) (*ConfigReport, error) {
+	return NewReadOnlyResource[ConfigReport](ci, hostsapi, ci.Hostname, "config_reports").Get(ctx, "last")
+}
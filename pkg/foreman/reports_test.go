@@ -0,0 +1,82 @@
+package foreman_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	reportsTimeout = 180
+	lastReport     = `{"id":42,"host_id":1,"host_name":"test","reported_at":"%s","origin":"Puppet",
+"status":{"applied":3,"restarted":0,"failed":%d,"failed_restarts":0,"skipped":1,"pending":0},
+"metrics":{"resources":{"total":120},"time":{"total":12.5}},
+"logs":[{"log":{"sources":{"source":"Package[httpd]"},"messages":{"message":"created"},"level":"notice"}}]}`
+)
+
+func ExampleConnectionInfo_GetLastConfigReport() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(fmt.Sprintf(lastReport, "2020-03-01 10:00:00 UTC", 0))))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, reportsTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client(), Hostname: "test"}
+	report, err := api.GetLastConfigReport(ctx)
+
+	fmt.Printf("%d %d %v %s %s %v", report.ID, report.Status.Applied, report.Metrics["time"]["total"], report.Logs[0].Source, report.Logs[0].Message, err)
+
+	// Output: 42 3 12.5 Package[httpd] created <nil>
+}
+
+func TestReportConverged(t *testing.T) {
+
+	tt := []struct {
+		name              string
+		reportedat        string
+		failed            int
+		expectedfailures  int
+		expectedoutofsync bool
+	}{
+		{name: "converged", reportedat: time.Now().UTC().Format("2006-01-02 15:04:05 MST"), failed: 0, expectedfailures: 0, expectedoutofsync: false},
+		{name: "failures", reportedat: time.Now().UTC().Format("2006-01-02 15:04:05 MST"), failed: 2, expectedfailures: 2, expectedoutofsync: false},
+		{name: "out of sync", reportedat: time.Now().Add(-time.Hour).UTC().Format(time.RFC3339), failed: 0, expectedfailures: 0, expectedoutofsync: true},
+	}
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, reportsTimeout*time.Second)
+	defer cancel()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				check(rw.Write([]byte(fmt.Sprintf(lastReport, tc.reportedat, tc.failed))))
+			}))
+			defer server.Close()
+
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client(), Hostname: "test"}
+			report, err := api.GetLastConfigReport(ctx)
+			if err != nil {
+				t.Fatalf("Could not read response %v correctly", err)
+			}
+			outOfSync, err := report.OutOfSync(35 * time.Minute)
+			if err != nil {
+				t.Fatalf("Could not parse reported time %v correctly", err)
+			}
+			if tc.expectedfailures != report.Failures() || tc.expectedoutofsync != outOfSync {
+				t.Errorf("Test %v result should be %v %v, got  `%v %v`", tc.name, tc.expectedfailures, tc.expectedoutofsync, report.Failures(), outOfSync)
+			}
+			log.Printf("Response: %v", report.Status)
+		})
+	}
+}
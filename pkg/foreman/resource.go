@@ -0,0 +1,159 @@
+package foreman
+
+// apidoc/v2.json is not an export of a foreman server, it was reconstructed offline and matches no single foreman release.
+// It describes the core resources including the puppet ones and those of the foreman_remote_execution, foreman-tasks,
+// foreman_ansible and foreman_webhooks plugins. Refresh it from the /apidoc/v2.json of a server to pin a version
+//go:generate go run ../../cmd/foreman-gen -in apidoc/v2.json -out generated.go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Resource provides typed list, get, create, update and delete calls for a foreman api collection whose members are T.
+// A new resource plugs in by declaring its type and an accessor returning NewResource, ids may be a numeric id or a name
+type Resource[T any] struct {
+	ci      *ConnectionInfo
+	wrapper string
+	elem    []string
+}
+
+// NewResource returns the resource at the api path elements, e.g. api/hosts/web01/parameters.
+// Members are wrapped in the wrapper name, e.g. parameter, when they are created or updated
+func NewResource[T any](ci *ConnectionInfo, wrapper string, elem ...string) *Resource[T] {
+	return &Resource[T]{ci: ci, wrapper: wrapper, elem: elem}
+}
+
+// List returns the members matching the search, an empty search returns all
+func (r *Resource[T]) List(ctx context.Context, search string) ([]T, error) {
+	var members []T
+	err := r.ci.list(ctx, search, &members, r.elem...)
+	return members, err
+}
+
+// Get returns the member with the id provided
+func (r *Resource[T]) Get(ctx context.Context, id string) (*T, error) {
+	return call[T](ctx, r.ci, http.MethodGet, nil, r.member(id)...)
+}
+
+// Create creates a new member
+func (r *Resource[T]) Create(ctx context.Context, member T) (*T, error) {
+	return call[T](ctx, r.ci, http.MethodPost, map[string]T{r.wrapper: member}, r.elem...)
+}
+
+// Update updates the member with the id provided, fields left empty are not changed.
+// Boolean fields are pointers so they can be set to false, types without omitempty fields are read through a ReadOnlyResource
+func (r *Resource[T]) Update(ctx context.Context, id string, member T) (*T, error) {
+	return call[T](ctx, r.ci, http.MethodPut, map[string]T{r.wrapper: member}, r.member(id)...)
+}
+
+// Delete deletes the member with the id provided
+func (r *Resource[T]) Delete(ctx context.Context, id string) error {
+	return r.ci.doJSON(ctx, http.MethodDelete, r.ci.apiURL(nil, r.member(id)...), nil, nil)
+}
+
+// Resolve returns the id of the member with the name or title provided
+func (r *Resource[T]) Resolve(ctx context.Context, name string) (int, error) {
+	return r.ci.resolveID(ctx, name, r.elem...)
+}
+
+// member returns the path elements of the member with the id provided
+func (r *Resource[T]) member(id string, elem ...string) []string {
+	return append(append(append([]string{}, r.elem...), id), elem...)
+}
+
+// ReadOnlyResource provides typed list and get calls for a foreman api collection whose members are T and that cannot be written
+// through the api or whose type is only decoded, e.g. audits or permissions
+type ReadOnlyResource[T any] struct {
+	resource *Resource[T]
+}
+
+// NewReadOnlyResource returns the read only resource at the api path elements, e.g. api/hosts/web01/config_reports
+func NewReadOnlyResource[T any](ci *ConnectionInfo, elem ...string) *ReadOnlyResource[T] {
+	return &ReadOnlyResource[T]{resource: NewResource[T](ci, "", elem...)}
+}
+
+// List returns the members matching the search, an empty search returns all
+func (r *ReadOnlyResource[T]) List(ctx context.Context, search string) ([]T, error) {
+	return r.resource.List(ctx, search)
+}
+
+// Get returns the member with the id provided
+func (r *ReadOnlyResource[T]) Get(ctx context.Context, id string) (*T, error) {
+	return r.resource.Get(ctx, id)
+}
+
+// Resolve returns the id of the member with the name or title provided
+func (r *ReadOnlyResource[T]) Resolve(ctx context.Context, name string) (int, error) {
+	return r.resource.Resolve(ctx, name)
+}
+
+// call sends in as the json payload to the api path elements and decodes the response as Out
+func call[Out any](ctx context.Context, ci *ConnectionInfo, method string, in interface{}, elem ...string) (*Out, error) {
+	var out Out
+	err := ci.doJSON(ctx, method, ci.apiURL(nil, elem...), in, &out)
+	return &out, err
+}
+
+// action calls an action of the api path elements that is not list, get, create, update or delete and returns the response body,
+// params are sent as the query of GET requests and as the json payload of the others, they may be nil
+func (ci *ConnectionInfo) action(ctx context.Context, method string, params interface{}, elem ...string) ([]byte, error) {
+
+	var query url.Values
+	var data []byte
+	var err error
+
+	if params != nil && method == http.MethodGet {
+		query, err = queryValues(params)
+	} else if params != nil {
+		data, err = json.Marshal(params)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ci.doRequest(ctx, method, ci.apiURL(query, elem...), data)
+}
+
+// queryValues returns the json fields of params as a rails query, arrays become key[] and hashes key[name]
+func queryValues(params interface{}) (url.Values, error) {
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	var add func(key string, v interface{})
+	add = func(key string, v interface{}) {
+		switch v := v.(type) {
+		case nil:
+		case []interface{}:
+			for _, e := range v {
+				add(key+"[]", e)
+			}
+		case map[string]interface{}:
+			for name, e := range v {
+				add(key+"["+name+"]", e)
+			}
+		default:
+			query.Add(key, fmt.Sprint(v))
+		}
+	}
+	for key, v := range fields {
+		add(key, v)
+	}
+
+	return query, nil
+}
@@ -0,0 +1,383 @@
+package foreman_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+	"github.com/bishy999/go-foreman/pkg/foremantest"
+)
+
+const (
+	resourceTimeout = 180
+)
+
+func ExampleResource() {
+
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	server.AddHostgroup(foremantest.Hostgroup{ID: 3, Name: "web"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), resourceTimeout*time.Second)
+	defer cancel()
+
+	api := server.ConnectionInfo()
+
+	created, err := api.HostsResource().Create(ctx, foreman.Host{Name: "web01.example.com", HostgroupID: 3})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	host, err := api.HostsResource().Get(ctx, "web01.example.com")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(host.ID == created.ID, host.Name, host.HostgroupID)
+	// Output: true web01.example.com 3
+}
+
+// testResource runs the create, get, list, resolve, update and delete calls of the resource in order
+func testResource[T any](t *testing.T, r *foreman.Resource[T], create T, update T, name func(T) string, id func(T) int) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), resourceTimeout*time.Second)
+	defer cancel()
+
+	created, err := r.Create(ctx, create)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if id(*created) == 0 || name(*created) != name(create) {
+		t.Fatalf("create: got %+v", *created)
+	}
+	memberID := strconv.Itoa(id(*created))
+
+	got, err := r.Get(ctx, memberID)
+	if err != nil || name(*got) != name(create) {
+		t.Fatalf("get: got %+v, %v", got, err)
+	}
+
+	members, err := r.List(ctx, "name = "+name(create))
+	if err != nil || len(members) != 1 {
+		t.Fatalf("list: got %d members, %v", len(members), err)
+	}
+
+	resolved, err := r.Resolve(ctx, name(create))
+	if err != nil || resolved != id(*created) {
+		t.Fatalf("resolve: got %d, %v", resolved, err)
+	}
+
+	updated, err := r.Update(ctx, memberID, update)
+	if err != nil || name(*updated) != name(update) {
+		t.Fatalf("update: got %+v, %v", updated, err)
+	}
+
+	if _, err := r.Create(ctx, update); !isStatus(err, http.StatusUnprocessableEntity) {
+		t.Errorf("create duplicate: expected 422, got %v", err)
+	}
+
+	if err := r.Delete(ctx, memberID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	if _, err := r.Get(ctx, memberID); !isStatus(err, http.StatusNotFound) {
+		t.Errorf("get deleted: expected 404, got %v", err)
+	}
+
+	if _, err := r.Resolve(ctx, name(update)); !errors.Is(err, foreman.ErrNotFound) {
+		t.Errorf("resolve deleted: expected not found, got %v", err)
+	}
+}
+
+// isStatus reports whether err is an api error with the status code provided
+func isStatus(err error, code int) bool {
+	var apiErr *foreman.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == code
+}
+
+func TestResource(t *testing.T) {
+
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	api := server.ConnectionInfo()
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T)
+	}{
+		{"hosts", func(t *testing.T) {
+			testResource(t, api.HostsResource(),
+				foreman.Host{Name: "web01.example.com", Comment: "created"}, foreman.Host{Name: "web02.example.com"},
+				func(h foreman.Host) string { return h.Name }, func(h foreman.Host) int { return h.ID })
+		}},
+		{"hostgroups", func(t *testing.T) {
+			testResource(t, api.HostgroupsResource(),
+				foreman.Hostgroup{Name: "web"}, foreman.Hostgroup{Name: "db"},
+				func(hg foreman.Hostgroup) string { return hg.Name }, func(hg foreman.Hostgroup) int { return hg.ID })
+		}},
+		{"compute profiles", func(t *testing.T) {
+			testResource(t, api.ComputeProfilesResource(),
+				foreman.ComputeProfile{Name: "small"}, foreman.ComputeProfile{Name: "large"},
+				func(cp foreman.ComputeProfile) string { return cp.Name }, func(cp foreman.ComputeProfile) int { return cp.ID })
+		}},
+		{"organizations", func(t *testing.T) {
+			testResource(t, api.OrganizationsResource(),
+				foreman.Organization{Name: "engineering"}, foreman.Organization{Name: "finance"},
+				func(o foreman.Organization) string { return o.Name }, func(o foreman.Organization) int { return o.ID })
+		}},
+		{"locations", func(t *testing.T) {
+			testResource(t, api.LocationsResource(),
+				foreman.Location{Name: "dublin"}, foreman.Location{Name: "cork"},
+				func(l foreman.Location) string { return l.Name }, func(l foreman.Location) int { return l.ID })
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, tc.run)
+	}
+}
+
+func TestResourceValidation(t *testing.T) {
+
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	api := server.ConnectionInfo()
+
+	ctx, cancel := context.WithTimeout(context.Background(), resourceTimeout*time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name string
+		host foreman.Host
+		code int
+	}{
+		{"blank name", foreman.Host{}, http.StatusUnprocessableEntity},
+		{"unknown hostgroup", foreman.Host{Name: "web01", HostgroupID: 99}, http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := api.HostsResource().Create(ctx, tc.host)
+			if !isStatus(err, tc.code) {
+				t.Errorf("expected %d, got %v", tc.code, err)
+			}
+		})
+	}
+}
+
+func TestResolveTitle(t *testing.T) {
+
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	server.AddHostgroup(foremantest.Hostgroup{ID: 1, Name: "web"})
+	server.AddHostgroup(foremantest.Hostgroup{ID: 2, Name: "frontend", Title: "web/frontend"})
+	server.AddHostgroup(foremantest.Hostgroup{ID: 3, Name: "frontend", Title: "db/frontend"})
+
+	api := server.ConnectionInfo()
+
+	ctx, cancel := context.WithTimeout(context.Background(), resourceTimeout*time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name        string
+		value       string
+		expectedid  int
+		expectederr error
+	}{
+		{"name", "web", 1, nil},
+		{"nested title", "web/frontend", 2, nil},
+		{"name shared by nested hostgroups", "frontend", 0, foreman.ErrAmbiguous},
+		{"unknown title", "web/backend", 0, foreman.ErrNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			id, err := api.ResolveHostgroup(ctx, tc.value)
+			if tc.expectederr != nil {
+				if !errors.Is(err, tc.expectederr) {
+					t.Fatalf("Test %v error should be %v, got `%v`", tc.name, tc.expectederr, err)
+				}
+				return
+			}
+			if err != nil || id != tc.expectedid {
+				t.Errorf("Test %v result should be %v, got `%v` %v", tc.name, tc.expectedid, id, err)
+			}
+		})
+	}
+}
+
+func TestResourceID(t *testing.T) {
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.RequestURI)
+		check(rw.Write([]byte(`{}`)))
+	}))
+	defer server.Close()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), resourceTimeout*time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name             string
+		id               string
+		expectedrequests []string
+		expectederr      error
+	}{
+		{"name", "web01.example.com", []string{"DELETE /api/hosts/web01.example.com"}, nil},
+		{"escaped", "web 01?x=1", []string{"DELETE /api/hosts/web%2001%3Fx=1"}, nil},
+		{"traversal", "../users/1", nil, foreman.ErrInvalidID},
+		{"slash", "users/1", nil, foreman.ErrInvalidID},
+		{"dot dot", "..", nil, foreman.ErrInvalidID},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			requests = nil
+			err := api.HostsResource().Delete(ctx, tc.id)
+			if !errors.Is(err, tc.expectederr) {
+				t.Fatalf("Test %v error should be %v, got `%v`", tc.name, tc.expectederr, err)
+			}
+			if fmt.Sprint(requests) != fmt.Sprint(tc.expectedrequests) {
+				t.Errorf("Test %v result should be %v, got  `%v`", tc.name, tc.expectedrequests, requests)
+			}
+		})
+	}
+}
+
+func TestResourceUpdate(t *testing.T) {
+
+	var request string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		data, _ := ioutil.ReadAll(req.Body)
+		request = req.Method + " " + req.URL.Path + " " + string(data)
+		check(rw.Write([]byte(`{"id":4,"name":"web01.example.com","enabled":false}`)))
+	}))
+	defer server.Close()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), resourceTimeout*time.Second)
+	defer cancel()
+
+	enabled := false
+	host, err := api.HostsResource().Update(ctx, "4", foreman.Host{Enabled: &enabled})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `PUT /api/hosts/4 {"host":{"enabled":false}}`
+	if request != expected || host.Enabled == nil || *host.Enabled {
+		t.Errorf("Test update result should be %v, got  `%v` %+v", expected, request, host)
+	}
+}
+
+func TestResourceAction(t *testing.T) {
+
+	var request string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		data, _ := ioutil.ReadAll(req.Body)
+		request = strings.TrimSpace(req.Method + " " + req.URL.RequestURI() + " " + string(data))
+		check(rw.Write([]byte(`<%#` + "\n" + `name: Host - Statuses` + "\n" + `%>`)))
+	}))
+	defer server.Close()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), resourceTimeout*time.Second)
+	defer cancel()
+
+	gzip := false
+	tests := []struct {
+		name     string
+		call     func() ([]byte, error)
+		expected string
+	}{
+		{"member", func() ([]byte, error) { return api.ReportTemplateExport(ctx, "7") }, "GET /api/report_templates/7/export"},
+		{"member with params", func() ([]byte, error) {
+			return api.ReportTemplateGenerate(ctx, "7", foreman.ReportTemplateGenerateParams{Gzip: &gzip})
+		}, `POST /api/report_templates/7/generate {"gzip":false}`},
+		{"member with two ids", func() ([]byte, error) { return api.ReportTemplateReportData(ctx, "7", "5") }, "GET /api/report_templates/7/report_data/5"},
+		{"collection", func() ([]byte, error) { return api.WebhooksEvents(ctx) }, "GET /api/webhooks/events"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			body, err := tc.call()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if request != tc.expected || !strings.Contains(string(body), "Host - Statuses") {
+				t.Errorf("Test %v result should be %v, got  `%v`", tc.name, tc.expected, request)
+			}
+		})
+	}
+}
+
+func TestResourceRequests(t *testing.T) {
+
+	var request string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		data, _ := ioutil.ReadAll(req.Body)
+		request = strings.TrimSpace(req.Method + " " + req.URL.Path + " " + string(data))
+		check(rw.Write([]byte(`{"name":"dev99"}`)))
+	}))
+	defer server.Close()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client(), Hostname: "dev99"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), resourceTimeout*time.Second)
+	defer cancel()
+
+	snippet := false
+	tests := []struct {
+		name     string
+		call     func() error
+		expected string
+	}{
+		{"update job template", func() error {
+			_, err := api.JobTemplatesResource().Update(ctx, "3", foreman.JobTemplate{Snippet: &snippet})
+			return err
+		}, `PUT /api/job_templates/3 {"job_template":{"snippet":false}}`},
+		{"update setting", func() error {
+			_, err := api.UpdateSetting(ctx, "token_duration", 360)
+			return err
+		}, `PUT /api/settings/token_duration {"setting":{"value":360}}`},
+		{"get task", func() error {
+			_, err := api.GetTask(ctx, "a1b2")
+			return err
+		}, "GET /api/foreman_tasks/a1b2"},
+		{"cancel task", func() error { return api.CancelTask(ctx, "a1b2") }, "POST /api/foreman_tasks/a1b2/cancel"},
+		{"last config report", func() error {
+			_, err := api.GetLastConfigReport(ctx)
+			return err
+		}, "GET /api/hosts/dev99/config_reports/last"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.call(); err != nil {
+				t.Fatal(err)
+			}
+			if request != tc.expected {
+				t.Errorf("Test %v result should be %v, got  `%v`", tc.name, tc.expected, request)
+			}
+		})
+	}
+}
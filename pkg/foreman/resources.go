@@ -0,0 +1,290 @@
+package foreman
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	perPage = 100
+)
+
+// ErrNotFound is wrapped by the errors returned when a resource cannot be resolved by name
+var ErrNotFound = errors.New("not found")
+
+// ErrAmbiguous is wrapped by the errors returned when a name or selector matches more than one resource
+var ErrAmbiguous = errors.New("is ambiguous")
+
+// ErrInvalidID is wrapped by the errors returned when an id or name cannot be used as a single segment of an api path
+var ErrInvalidID = errors.New("is not a valid id")
+
+// APIError is returned when foreman responds with an unsuccessful status code
+type APIError struct {
+	StatusCode int
+	Message    string
+	Body       string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("foreman api returned %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("foreman api returned %d: %s", e.StatusCode, e.Body)
+}
+
+// errorResp contains the error body returned by foreman
+type errorResp struct {
+	Error struct {
+		Message  string   `json:"message"`
+		FullMsgs []string `json:"full_messages"`
+	} `json:"error"`
+}
+
+// listResp contains the envelope foreman wraps around every index call
+type listResp struct {
+	Total    int             `json:"total"`
+	Subtotal int             `json:"subtotal"`
+	Page     int             `json:"page"`
+	PerPage  int             `json:"per_page"`
+	Search   string          `json:"search"`
+	Results  json.RawMessage `json:"results"`
+}
+
+// requestURL is the url of an api call, err is set when it could not be built
+type requestURL struct {
+	url string
+	err error
+}
+
+// apiURL joins the api path elements onto the base url. The first element is the path of the collection,
+// every other element is a single escaped segment, ids that are dot segments or contain a slash are rejected
+// so they cannot address another endpoint, empty elements are left out
+func (ci *ConnectionInfo) apiURL(query url.Values, elem ...string) requestURL {
+
+	u, err := url.Parse(ci.BaseURL)
+	if err != nil {
+		return requestURL{err: err}
+	}
+
+	var segments []string
+	for i, e := range elem {
+		if i == 0 {
+			segments = append(segments, strings.Split(strings.Trim(e, "/"), "/")...)
+			continue
+		}
+		if e == "." || e == ".." || strings.Contains(e, "/") {
+			return requestURL{err: fmt.Errorf("[%s] %w", e, ErrInvalidID)}
+		}
+		if e != "" {
+			segments = append(segments, e)
+		}
+	}
+
+	escaped := make([]string, 0, len(segments))
+	for _, s := range segments {
+		escaped = append(escaped, url.PathEscape(s))
+	}
+	u.RawPath = strings.TrimSuffix(u.EscapedPath(), "/") + "/" + strings.Join(escaped, "/")
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + strings.Join(segments, "/")
+	if query != nil {
+		u.RawQuery = query.Encode()
+	}
+
+	return requestURL{url: u.String()}
+}
+
+// doRequest sends an http request with an optional json payload and returns the response body,
+// any status code outside of 2xx is returned as an APIError
+func (ci *ConnectionInfo) doRequest(ctx context.Context, method string, api requestURL, data []byte) ([]byte, error) {
+
+	if api.err != nil {
+		return nil, api.err
+	}
+
+	resp, respBody, err := ci.send(ctx, method, api.url, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Body: string(respBody)}
+		var e errorResp
+		if json.Unmarshal(respBody, &e) == nil {
+			apiErr.Message = e.Error.Message
+			if apiErr.Message == "" && len(e.Error.FullMsgs) > 0 {
+				apiErr.Message = e.Error.FullMsgs[0]
+			}
+		}
+		return respBody, apiErr
+	}
+
+	return respBody, nil
+}
+
+// send is the single path every api request goes through, it authenticates the request, starts a span
+// and sends it through the middleware chain of the connection before reading the response
+func (ci *ConnectionInfo) send(ctx context.Context, method string, api string, data []byte) (*http.Response, []byte, error) {
+
+	req, err := http.NewRequest(method, api, bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req = req.WithContext(ctx)
+	req.SetBasicAuth(ci.Username, ci.Password)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	req, span := ci.startSpan(req, apiEndpoint(req.URL.Path))
+
+	resp, err := ci.doer().Do(req)
+	if err != nil {
+		span.End(0, err)
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	span.End(resp.StatusCode, err)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp, respBody, nil
+}
+
+// doJSON marshals in as the request payload and decodes the response into out, both may be nil
+func (ci *ConnectionInfo) doJSON(ctx context.Context, method string, api requestURL, in interface{}, out interface{}) error {
+
+	var data []byte
+	var err error
+
+	if in != nil {
+		data, err = json.Marshal(in)
+		if err != nil {
+			return err
+		}
+	}
+
+	body, err := ci.doRequest(ctx, method, api, data)
+	if err != nil {
+		return err
+	}
+
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(body, out)
+}
+
+// list pages through an index endpoint and decodes every result into out, which should be a pointer to a slice
+func (ci *ConnectionInfo) list(ctx context.Context, search string, out interface{}, elem ...string) error {
+
+	var results []json.RawMessage
+
+	err := ci.pages(ctx, search, func(page json.RawMessage) (int, error) {
+		var pageResults []json.RawMessage
+		if len(page) > 0 {
+			if err := json.Unmarshal(page, &pageResults); err != nil {
+				return 0, err
+			}
+		}
+		results = append(results, pageResults...)
+		return len(pageResults), nil
+	}, elem...)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, out)
+}
+
+// pages requests the pages of an index endpoint in turn and passes the results of each to add, which returns how many it counted.
+// Paging stops at the first page without results or once the subtotal is reached
+func (ci *ConnectionInfo) pages(ctx context.Context, search string, add func(json.RawMessage) (int, error), elem ...string) error {
+
+	count := 0
+
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("per_page", strconv.Itoa(perPage))
+		if search != "" {
+			query.Set("search", search)
+		}
+
+		var resp listResp
+		err := ci.doJSON(ctx, http.MethodGet, ci.apiURL(query, elem...), nil, &resp)
+		if err != nil {
+			return err
+		}
+
+		pageCount, err := add(resp.Results)
+		if err != nil {
+			return err
+		}
+		count += pageCount
+
+		if pageCount == 0 || count >= resp.Subtotal {
+			return nil
+		}
+	}
+}
+
+// titledResources are the collections whose members have a title, e.g. CentOS 7.9 or parent/child, as well as a name
+var titledResources = map[string]bool{operatingsystemsapi: true, hostgroupsapi: true, organizationsapi: true, locationsapi: true}
+
+// resolveID returns the id of the resource with the given name or title, numeric names are treated as ids
+func (ci *ConnectionInfo) resolveID(ctx context.Context, name string, elem ...string) (int, error) {
+
+	if id, err := strconv.Atoi(name); err == nil {
+		return id, nil
+	}
+
+	search := fmt.Sprintf("name = %q", name)
+	if titledResources[path.Join(elem...)] {
+		search = fmt.Sprintf("name = %q or title = %q", name, name)
+	}
+
+	var found []struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name"`
+		Title string `json:"title"`
+	}
+	err := ci.list(ctx, search, &found, elem...)
+	if err != nil {
+		return 0, err
+	}
+
+	var matches []int
+	for _, f := range found {
+		if f.Name == name || f.Title == name {
+			matches = append(matches, f.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return 0, fmt.Errorf("%s [%s] %w", path.Base(path.Join(elem...)), name, ErrNotFound)
+	case 1:
+		return matches[0], nil
+	default:
+		return 0, fmt.Errorf("%s [%s] %w, %d matches found", path.Base(path.Join(elem...)), name, ErrAmbiguous, len(matches))
+	}
+}
@@ -0,0 +1,113 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+const (
+	rolesapi       = "api/roles"
+	filtersapi     = "api/filters"
+	permissionsapi = "api/permissions"
+)
+
+// Role represents a foreman role, a named set of filters
+type Role struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Builtin     int    `json:"builtin,omitempty"`
+}
+
+// Filter grants a role permissions on a resource type, optionally limited by a search
+type Filter struct {
+	ID            int    `json:"id,omitempty"`
+	Search        string `json:"search,omitempty"`
+	ResourceType  string `json:"resource_type,omitempty"`
+	Unlimited     *bool  `json:"unlimited,omitempty"`
+	RoleID        int    `json:"role_id,omitempty"`
+	PermissionIDs []int  `json:"permission_ids,omitempty"`
+	Role          *struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"role,omitempty"`
+	Permissions []Permission `json:"permissions,omitempty"`
+}
+
+// Permission represents a single foreman permission such as view_hosts
+type Permission struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	ResourceType string `json:"resource_type"`
+}
+
+// RolesResource returns the typed resource for roles
+func (ci *ConnectionInfo) RolesResource() *Resource[Role] {
+	return NewResource[Role](ci, "role", rolesapi)
+}
+
+// FiltersResource returns the typed resource for filters
+func (ci *ConnectionInfo) FiltersResource() *Resource[Filter] {
+	return NewResource[Filter](ci, "filter", filtersapi)
+}
+
+// PermissionsResource returns the typed read only resource for permissions
+func (ci *ConnectionInfo) PermissionsResource() *ReadOnlyResource[Permission] {
+	return NewReadOnlyResource[Permission](ci, permissionsapi)
+}
+
+// ListRoles returns the roles matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListRoles(ctx context.Context, search string) ([]Role, error) {
+	return ci.RolesResource().List(ctx, search)
+}
+
+// GetRole returns the role with the id provided
+func (ci *ConnectionInfo) GetRole(ctx context.Context, id int) (*Role, error) {
+	return ci.RolesResource().Get(ctx, strconv.Itoa(id))
+}
+
+// CreateRole creates a new role
+func (ci *ConnectionInfo) CreateRole(ctx context.Context, role Role) (*Role, error) {
+	return ci.RolesResource().Create(ctx, role)
+}
+
+// UpdateRole updates the role with the id provided
+func (ci *ConnectionInfo) UpdateRole(ctx context.Context, id int, role Role) (*Role, error) {
+	return ci.RolesResource().Update(ctx, strconv.Itoa(id), role)
+}
+
+// DeleteRole deletes the role with the id provided
+func (ci *ConnectionInfo) DeleteRole(ctx context.Context, id int) error {
+	return ci.RolesResource().Delete(ctx, strconv.Itoa(id))
+}
+
+// ResolveRole returns the id of the role with the name provided
+func (ci *ConnectionInfo) ResolveRole(ctx context.Context, name string) (int, error) {
+	return ci.RolesResource().Resolve(ctx, name)
+}
+
+// ListFilters returns the filters matching the search, e.g. role_id = 3
+func (ci *ConnectionInfo) ListFilters(ctx context.Context, search string) ([]Filter, error) {
+	return ci.FiltersResource().List(ctx, search)
+}
+
+// ListRoleFilters returns the filters of the role with the id provided
+func (ci *ConnectionInfo) ListRoleFilters(ctx context.Context, roleID int) ([]Filter, error) {
+	return ci.ListFilters(ctx, fmt.Sprintf("role_id = %d", roleID))
+}
+
+// CreateFilter adds a filter to a role
+func (ci *ConnectionInfo) CreateFilter(ctx context.Context, filter Filter) (*Filter, error) {
+	return ci.FiltersResource().Create(ctx, filter)
+}
+
+// DeleteFilter deletes the filter with the id provided
+func (ci *ConnectionInfo) DeleteFilter(ctx context.Context, id int) error {
+	return ci.FiltersResource().Delete(ctx, strconv.Itoa(id))
+}
+
+// ListPermissions returns the permissions matching the search, e.g. resource_type = Host
+func (ci *ConnectionInfo) ListPermissions(ctx context.Context, search string) ([]Permission, error) {
+	return ci.PermissionsResource().List(ctx, search)
+}
@@ -0,0 +1,58 @@
+package foreman_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	rolesTimeout = 180
+)
+
+func ExampleConnectionInfo_ListRoleFilters() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(`{"total":1,"subtotal":1,"page":1,"per_page":100,"results":[{"id":7,"resource_type":"Host","unlimited":true,"role":{"id":3,"name":"Viewer"},"permissions":[{"id":1,"name":"view_hosts","resource_type":"Host"}]}]}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, rolesTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	filters, err := api.ListRoleFilters(ctx, 3)
+
+	fmt.Printf("%s %s %s %v", filters[0].ResourceType, filters[0].Role.Name, filters[0].Permissions[0].Name, err)
+
+	// Output: Host Viewer view_hosts <nil>
+}
+
+func TestListRoleFiltersSearch(t *testing.T) {
+
+	var search string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		search = req.URL.Query().Get("search")
+		check(rw.Write([]byte(`{"total":0,"subtotal":0,"page":1,"per_page":100,"results":[]}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, rolesTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	filters, err := api.ListRoleFilters(ctx, 3)
+	if err != nil {
+		t.Fatalf("Could not read response %v correctly", err)
+	}
+	if search != "role_id = 3" || len(filters) != 0 {
+		t.Errorf("unexpected search `%v` or filters `%v`", search, filters)
+	}
+}
@@ -0,0 +1,279 @@
+package foreman
+
+import (
+	"context"
+	"time"
+)
+
+// StatusService checks the connection to the foreman api
+type StatusService interface {
+	CheckStatus(ctx context.Context) (bool, string, error)
+}
+
+// HostsService manages the lifecycle of the host set in Hostname
+type HostsService interface {
+	CheckHost(ctx context.Context) (bool, string, error)
+	CreateHost(ctx context.Context) (bool, string, error)
+	DeleteHost(ctx context.Context) (bool, string, error)
+	RebuildHost(ctx context.Context, powerCycle bool) error
+	CancelBuild(ctx context.Context) error
+	PowerHost(ctx context.Context, action string) error
+	GetBuildStatus(ctx context.Context) (*BuildStatus, error)
+	RebuildConfig(ctx context.Context, only ...string) error
+	GetVMComputeAttributes(ctx context.Context) (map[string]interface{}, error)
+	ResolveHost(ctx context.Context, name string) (int, error)
+	GetHostBy(ctx context.Context, selector string) (*Host, error)
+}
+
+// BulkHostsService applies actions to every host matching a search
+type BulkHostsService interface {
+	BulkDestroyHosts(ctx context.Context, search string) ([]BulkHostResult, error)
+	BulkReassignHostgroup(ctx context.Context, search string, hostgroupID int) ([]BulkHostResult, error)
+	BulkChangeOwner(ctx context.Context, search string, ownerID int, ownerType string) ([]BulkHostResult, error)
+	BulkChangeEnvironment(ctx context.Context, search string, environmentID int) ([]BulkHostResult, error)
+	BulkBuild(ctx context.Context, search string, reboot bool) ([]BulkHostResult, error)
+	BulkPower(ctx context.Context, search string, action string) ([]BulkHostResult, error)
+}
+
+// HostgroupsService looks up hostgroups
+type HostgroupsService interface {
+	ResolveHostgroup(ctx context.Context, name string) (int, error)
+}
+
+// TaxonomiesService looks up organizations and locations
+type TaxonomiesService interface {
+	ListOrganizations(ctx context.Context, search string) ([]Organization, error)
+	ResolveOrganization(ctx context.Context, name string) (int, error)
+	ListLocations(ctx context.Context, search string) ([]Location, error)
+	ResolveLocation(ctx context.Context, name string) (int, error)
+}
+
+// OperatingSystemsService manages operating systems
+type OperatingSystemsService interface {
+	ListOperatingSystems(ctx context.Context, search string) ([]OperatingSystem, error)
+	GetOperatingSystem(ctx context.Context, id int) (*OperatingSystem, error)
+	CreateOperatingSystem(ctx context.Context, os OperatingSystem) (*OperatingSystem, error)
+	UpdateOperatingSystem(ctx context.Context, id int, os OperatingSystem) (*OperatingSystem, error)
+	ResolveOperatingSystem(ctx context.Context, name string) (int, error)
+}
+
+// ArchitecturesService manages architectures
+type ArchitecturesService interface {
+	ListArchitectures(ctx context.Context, search string) ([]Architecture, error)
+	GetArchitecture(ctx context.Context, id int) (*Architecture, error)
+	CreateArchitecture(ctx context.Context, arch Architecture) (*Architecture, error)
+	UpdateArchitecture(ctx context.Context, id int, arch Architecture) (*Architecture, error)
+	ResolveArchitecture(ctx context.Context, name string) (int, error)
+}
+
+// MediaService manages installation media
+type MediaService interface {
+	ListMedia(ctx context.Context, search string) ([]Medium, error)
+	GetMedium(ctx context.Context, id int) (*Medium, error)
+	CreateMedium(ctx context.Context, medium Medium) (*Medium, error)
+	UpdateMedium(ctx context.Context, id int, medium Medium) (*Medium, error)
+	ResolveMedium(ctx context.Context, name string) (int, error)
+}
+
+// PartitionTablesService manages partition tables
+type PartitionTablesService interface {
+	ListPartitionTables(ctx context.Context, search string) ([]PartitionTable, error)
+	GetPartitionTable(ctx context.Context, id int) (*PartitionTable, error)
+	CreatePartitionTable(ctx context.Context, ptable PartitionTable) (*PartitionTable, error)
+	UpdatePartitionTable(ctx context.Context, id int, ptable PartitionTable) (*PartitionTable, error)
+	ResolvePartitionTable(ctx context.Context, name string) (int, error)
+}
+
+// ProvisioningTemplatesService manages and renders provisioning templates
+type ProvisioningTemplatesService interface {
+	ListProvisioningTemplates(ctx context.Context, search string) ([]ProvisioningTemplate, error)
+	GetProvisioningTemplate(ctx context.Context, id int) (*ProvisioningTemplate, error)
+	CreateProvisioningTemplate(ctx context.Context, template ProvisioningTemplate) (*ProvisioningTemplate, error)
+	UpdateProvisioningTemplate(ctx context.Context, id int, template ProvisioningTemplate) (*ProvisioningTemplate, error)
+	CloneProvisioningTemplate(ctx context.Context, id int, name string) (*ProvisioningTemplate, error)
+	LockProvisioningTemplate(ctx context.Context, id int) (*ProvisioningTemplate, error)
+	UnlockProvisioningTemplate(ctx context.Context, id int) (*ProvisioningTemplate, error)
+	AssociateProvisioningTemplate(ctx context.Context, id int, operatingSystemIDs []int) (*ProvisioningTemplate, error)
+	RenderProvisioningTemplate(ctx context.Context, id int, hostID int) (string, error)
+	RenderHostTemplate(ctx context.Context, hostname string, kind string) (string, error)
+	ResolveProvisioningTemplate(ctx context.Context, name string) (int, error)
+}
+
+// FactsService queries facts reported by hosts
+type FactsService interface {
+	GetHostFacts(ctx context.Context) (map[string]string, error)
+	ListFactValues(ctx context.Context, search string) (FactValues, error)
+	SearchHostsByFact(ctx context.Context, fact string, value string) ([]string, error)
+}
+
+// ConfigReportsService queries configuration management reports
+type ConfigReportsService interface {
+	ListConfigReports(ctx context.Context, search string) ([]ConfigReport, error)
+	GetConfigReport(ctx context.Context, id int) (*ConfigReport, error)
+	GetLastConfigReport(ctx context.Context) (*ConfigReport, error)
+}
+
+// JobsService runs and follows remote execution jobs
+type JobsService interface {
+	ListJobTemplates(ctx context.Context, search string) ([]JobTemplate, error)
+	ResolveJobTemplate(ctx context.Context, name string) (int, error)
+	CreateJobInvocation(ctx context.Context, templateID int, search string, inputs map[string]string) (*JobInvocation, error)
+	GetJobInvocation(ctx context.Context, id int) (*JobInvocation, error)
+	WaitJobInvocation(ctx context.Context, id int, interval time.Duration) (*JobInvocation, error)
+	ListJobInvocationHosts(ctx context.Context, id int) ([]JobInvocationHost, error)
+	GetJobOutput(ctx context.Context, id int, hostID int) (*JobOutput, error)
+}
+
+// TasksService follows foreman tasks
+type TasksService interface {
+	ListTasks(ctx context.Context, search string) ([]Task, error)
+	GetTask(ctx context.Context, id string) (*Task, error)
+	CancelTask(ctx context.Context, id string) error
+	WaitTask(ctx context.Context, id string, interval time.Duration, progress func(*Task)) (*Task, error)
+}
+
+// AuditsService queries the audit log
+type AuditsService interface {
+	ListAudits(ctx context.Context, search string) ([]Audit, error)
+	FilterAudits(ctx context.Context, filter AuditFilter) ([]Audit, error)
+}
+
+// UsersService manages users
+type UsersService interface {
+	ListUsers(ctx context.Context, search string) ([]User, error)
+	GetUser(ctx context.Context, id int) (*User, error)
+	CreateUser(ctx context.Context, user User) (*User, error)
+	UpdateUser(ctx context.Context, id int, user User) (*User, error)
+	DeleteUser(ctx context.Context, id int) error
+	AssignUserRoles(ctx context.Context, id int, roleIDs []int) (*User, error)
+	AssignUserTaxonomies(ctx context.Context, id int, organizationIDs []int, locationIDs []int) (*User, error)
+	AddUserAssignments(ctx context.Context, id int, roleIDs []int, organizationIDs []int, locationIDs []int) (*User, error)
+	ResolveUser(ctx context.Context, login string) (int, error)
+}
+
+// UsergroupsService manages usergroups and their external groups
+type UsergroupsService interface {
+	ListUsergroups(ctx context.Context, search string) ([]Usergroup, error)
+	GetUsergroup(ctx context.Context, id int) (*Usergroup, error)
+	CreateUsergroup(ctx context.Context, group Usergroup) (*Usergroup, error)
+	UpdateUsergroup(ctx context.Context, id int, group Usergroup) (*Usergroup, error)
+	AddUsergroupMembers(ctx context.Context, id int, userIDs []int, roleIDs []int) (*Usergroup, error)
+	DeleteUsergroup(ctx context.Context, id int) error
+	ResolveUsergroup(ctx context.Context, name string) (int, error)
+	ListExternalUsergroups(ctx context.Context, usergroupID int) ([]ExternalUsergroup, error)
+	CreateExternalUsergroup(ctx context.Context, usergroupID int, group ExternalUsergroup) (*ExternalUsergroup, error)
+	DeleteExternalUsergroup(ctx context.Context, usergroupID int, id int) error
+	RefreshExternalUsergroup(ctx context.Context, usergroupID int, id int) error
+}
+
+// RolesService manages roles, their filters and the permissions they grant
+type RolesService interface {
+	ListRoles(ctx context.Context, search string) ([]Role, error)
+	GetRole(ctx context.Context, id int) (*Role, error)
+	CreateRole(ctx context.Context, role Role) (*Role, error)
+	UpdateRole(ctx context.Context, id int, role Role) (*Role, error)
+	DeleteRole(ctx context.Context, id int) error
+	ResolveRole(ctx context.Context, name string) (int, error)
+	ListFilters(ctx context.Context, search string) ([]Filter, error)
+	ListRoleFilters(ctx context.Context, roleID int) ([]Filter, error)
+	CreateFilter(ctx context.Context, filter Filter) (*Filter, error)
+	DeleteFilter(ctx context.Context, id int) error
+	ListPermissions(ctx context.Context, search string) ([]Permission, error)
+}
+
+// PersonalAccessTokensService manages the personal access tokens of users
+type PersonalAccessTokensService interface {
+	ListPersonalAccessTokens(ctx context.Context, userID int) ([]PersonalAccessToken, error)
+	GetPersonalAccessToken(ctx context.Context, userID int, id int) (*PersonalAccessToken, error)
+	CreatePersonalAccessToken(ctx context.Context, userID int, name string, expiresAt time.Time) (*PersonalAccessToken, error)
+	RevokePersonalAccessToken(ctx context.Context, userID int, id int) error
+}
+
+// PuppetService manages puppet classes, environments, smart class parameters and the ENC
+type PuppetService interface {
+	ListPuppetClasses(ctx context.Context, search string) ([]PuppetClass, error)
+	ResolvePuppetClass(ctx context.Context, name string) (int, error)
+	ListEnvironments(ctx context.Context, search string) ([]Environment, error)
+	ResolveEnvironment(ctx context.Context, name string) (int, error)
+	AddHostPuppetClass(ctx context.Context, classID int) error
+	RemoveHostPuppetClass(ctx context.Context, classID int) error
+	AddHostgroupPuppetClass(ctx context.Context, hostgroupID int, classID int) error
+	RemoveHostgroupPuppetClass(ctx context.Context, hostgroupID int, classID int) error
+	ImportPuppetClasses(ctx context.Context, proxyID int, environmentID int, dryrun bool) (map[string]interface{}, error)
+	ListSmartClassParameters(ctx context.Context, search string) ([]SmartClassParameter, error)
+	GetSmartClassParameter(ctx context.Context, id int) (*SmartClassParameter, error)
+	UpdateSmartClassParameter(ctx context.Context, id int, param SmartClassParameter) (*SmartClassParameter, error)
+	ResolveSmartClassParameter(ctx context.Context, class string, parameter string) (int, error)
+	ListOverrideValues(ctx context.Context, paramID int) ([]OverrideValue, error)
+	SetOverrideValue(ctx context.Context, paramID int, match string, value interface{}) (*OverrideValue, error)
+	DeleteOverrideValue(ctx context.Context, paramID int, id int) error
+	GetHostENC(ctx context.Context) (*ENC, error)
+}
+
+// AnsibleService manages ansible roles and variables
+type AnsibleService interface {
+	ListAnsibleRoles(ctx context.Context, search string) ([]AnsibleRole, error)
+	ResolveAnsibleRole(ctx context.Context, name string) (int, error)
+	ImportAnsibleRoles(ctx context.Context, proxyID int) (map[string]interface{}, error)
+	ListHostAnsibleRoles(ctx context.Context) ([]AnsibleRole, error)
+	AssignHostAnsibleRoles(ctx context.Context, roleIDs []int) error
+	PlayHostAnsibleRoles(ctx context.Context) (*JobInvocation, error)
+	ListHostgroupAnsibleRoles(ctx context.Context, hostgroupID int) ([]AnsibleRole, error)
+	AssignHostgroupAnsibleRoles(ctx context.Context, hostgroupID int, roleIDs []int) error
+	PlayHostgroupAnsibleRoles(ctx context.Context, hostgroupID int) (*JobInvocation, error)
+	ListAnsibleVariables(ctx context.Context, search string) ([]AnsibleVariable, error)
+	GetAnsibleVariable(ctx context.Context, id int) (*AnsibleVariable, error)
+	CreateAnsibleVariable(ctx context.Context, variable AnsibleVariable) (*AnsibleVariable, error)
+	UpdateAnsibleVariable(ctx context.Context, id int, variable AnsibleVariable) (*AnsibleVariable, error)
+	DeleteAnsibleVariable(ctx context.Context, id int) error
+	ResolveAnsibleVariable(ctx context.Context, name string) (int, error)
+	SetAnsibleOverrideValue(ctx context.Context, variableID int, match string, value interface{}) (*OverrideValue, error)
+	DeleteAnsibleOverrideValue(ctx context.Context, id int) error
+}
+
+// SettingsService reads and updates global settings
+type SettingsService interface {
+	ListSettings(ctx context.Context, search string) ([]Setting, error)
+	GetSetting(ctx context.Context, name string) (*Setting, error)
+	UpdateSetting(ctx context.Context, name string, value interface{}) (*Setting, error)
+	CheckSettings(ctx context.Context, expected map[string]interface{}) ([]SettingDrift, error)
+}
+
+// BookmarksService manages saved searches
+type BookmarksService interface {
+	ListBookmarks(ctx context.Context, search string) ([]Bookmark, error)
+	GetBookmark(ctx context.Context, id int) (*Bookmark, error)
+	CreateBookmark(ctx context.Context, bookmark Bookmark) (*Bookmark, error)
+	UpdateBookmark(ctx context.Context, id int, bookmark Bookmark) (*Bookmark, error)
+	DeleteBookmark(ctx context.Context, id int) error
+	ResolveBookmark(ctx context.Context, name string, controller string) (string, error)
+}
+
+// Client is implemented by ConnectionInfo and contains every service, depend on the narrowest service needed so it can be mocked
+type Client interface {
+	StatusService
+	HostsService
+	BulkHostsService
+	HostgroupsService
+	TaxonomiesService
+	OperatingSystemsService
+	ArchitecturesService
+	MediaService
+	PartitionTablesService
+	ProvisioningTemplatesService
+	FactsService
+	ConfigReportsService
+	JobsService
+	TasksService
+	AuditsService
+	UsersService
+	UsergroupsService
+	RolesService
+	PersonalAccessTokensService
+	PuppetService
+	AnsibleService
+	SettingsService
+	BookmarksService
+}
+
+var _ Client = (*ConnectionInfo)(nil)
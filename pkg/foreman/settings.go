@@ -0,0 +1,74 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+const (
+	settingsapi = "api/settings"
+)
+
+// Setting represents a global foreman setting
+type Setting struct {
+	Name         string      `json:"name,omitempty"`
+	FullName     string      `json:"full_name,omitempty"`
+	Description  string      `json:"description,omitempty"`
+	Category     string      `json:"category_name,omitempty"`
+	SettingsType string      `json:"settings_type,omitempty"`
+	Value        interface{} `json:"value,omitempty"`
+	Default      interface{} `json:"default,omitempty"`
+	Readonly     *bool       `json:"readonly,omitempty"`
+}
+
+// SettingDrift describes a setting whose value differs from the one expected
+type SettingDrift struct {
+	Name     string
+	Expected interface{}
+	Actual   interface{}
+}
+
+// SettingsResource returns the typed resource for global settings
+func (ci *ConnectionInfo) SettingsResource() *Resource[Setting] {
+	return NewResource[Setting](ci, "setting", settingsapi)
+}
+
+// ListSettings returns the settings matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListSettings(ctx context.Context, search string) ([]Setting, error) {
+	return ci.SettingsResource().List(ctx, search)
+}
+
+// GetSetting returns the setting with the name provided
+func (ci *ConnectionInfo) GetSetting(ctx context.Context, name string) (*Setting, error) {
+	return ci.SettingsResource().Get(ctx, name)
+}
+
+// UpdateSetting sets the value of the setting with the name provided, foreman casts the value to the setting type
+func (ci *ConnectionInfo) UpdateSetting(ctx context.Context, name string, value interface{}) (*Setting, error) {
+	return ci.SettingsResource().Update(ctx, name, Setting{Value: value})
+}
+
+// CheckSettings compares the expected values against foreman and returns the settings that have drifted,
+// values are compared by their string representation so "true" matches true
+func (ci *ConnectionInfo) CheckSettings(ctx context.Context, expected map[string]interface{}) ([]SettingDrift, error) {
+
+	names := make([]string, 0, len(expected))
+	for name := range expected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var drift []SettingDrift
+	for _, name := range names {
+		setting, err := ci.GetSetting(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if fmt.Sprint(setting.Value) != fmt.Sprint(expected[name]) {
+			drift = append(drift, SettingDrift{Name: name, Expected: expected[name], Actual: setting.Value})
+		}
+	}
+
+	return drift, nil
+}
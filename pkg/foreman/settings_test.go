@@ -0,0 +1,81 @@
+package foreman_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	settingsTimeout = 180
+)
+
+func ExampleConnectionInfo_UpdateSetting() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		log.Printf("Request: %s %s %s", req.Method, req.URL.Path, body)
+		check(rw.Write([]byte(`{"name":"token_duration","settings_type":"integer","value":360}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, settingsTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	setting, err := api.UpdateSetting(ctx, "token_duration", "360")
+
+	fmt.Printf("%s %v %v", setting.Name, setting.Value, err)
+
+	// Output: token_duration 360 <nil>
+}
+
+func TestCheckSettings(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch strings.TrimPrefix(req.URL.Path, "/api/settings/") {
+		case "destroy_vm_on_host_delete":
+			check(rw.Write([]byte(`{"name":"destroy_vm_on_host_delete","value":false}`)))
+		case "token_duration":
+			check(rw.Write([]byte(`{"name":"token_duration","value":360}`)))
+		default:
+			check(rw.Write([]byte(`{"name":"unattended_url","value":"http://foreman.example.com"}`)))
+		}
+	}))
+	defer server.Close()
+
+	tt := []struct {
+		name           string
+		expected       map[string]interface{}
+		expectedresult string
+	}{
+		{name: "no drift", expected: map[string]interface{}{"destroy_vm_on_host_delete": "false", "token_duration": 360.0}, expectedresult: "[]"},
+		{name: "drift", expected: map[string]interface{}{"destroy_vm_on_host_delete": true, "unattended_url": "https://foreman.example.com"}, expectedresult: "[{destroy_vm_on_host_delete true false} {unattended_url https://foreman.example.com http://foreman.example.com}]"},
+	}
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, settingsTimeout*time.Second)
+	defer cancel()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+			drift, err := api.CheckSettings(ctx, tc.expected)
+			if err != nil {
+				t.Fatalf("Could not read response %v correctly", err)
+			}
+			if tc.expectedresult != fmt.Sprint(drift) {
+				t.Errorf("Test %v result should be %v, got  `%v`", tc.name, tc.expectedresult, drift)
+			}
+		})
+	}
+}
@@ -2,10 +2,8 @@ package foreman
 
 import (
 	"context"
-	"log"
+	"errors"
 	"net/http"
-	"net/url"
-	"path"
 )
 
 const (
@@ -23,23 +21,31 @@ type ConnectionInfo struct {
 	Group    int
 	Profile  string
 	Action   string
+
+	Logger      Logger
+	Metrics     Metrics
+	Tracer      Tracer
+	RateLimiter *RateLimiter
+	Middleware  []Middleware
+
+	OperatingSystem string
+	Architecture    string
+	Medium          string
+	PartitionTable  string
 }
 
 // CheckStatus check to see if successfully connected to api
 func (cli *ConnectionInfo) CheckStatus(ctx context.Context) (bool, string, error) {
 
-	var jsonData []byte
-	var name string
-
-	url, _ := url.Parse(cli.BaseURL)
-	url.Path = path.Join(url.Path, statusapi)
-	apiused := url.String()
-	log.Printf("API Used: %s", apiused)
-
-	exists, status, err := sendRequest(ctx, name, cli.Username, cli.Password, cli.Client, jsonData, apiused, http.MethodGet)
+	body, err := cli.doRequest(ctx, http.MethodGet, cli.apiURL(nil, statusapi), nil)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		// an api answering with an error status is reported through the status rather than as an error
+		return false, string(body), nil
+	}
 	if err != nil {
-		log.Fatalf("Error: %s", err.Error())
+		return false, "", err
 	}
 
-	return exists, status, err
+	return true, string(body), nil
 }
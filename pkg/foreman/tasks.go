@@ -0,0 +1,135 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	tasksapi = "api/foreman_tasks"
+
+	// defaultWaitInterval is used by Wait when the interval provided is not positive
+	defaultWaitInterval = 5 * time.Second
+)
+
+// Task represents an asynchronous foreman task (dynflow action) returned by many plugin operations
+type Task struct {
+	ID        string  `json:"id"`
+	Label     string  `json:"label"`
+	Action    string  `json:"action"`
+	Username  string  `json:"username"`
+	Pending   bool    `json:"pending"`
+	State     string  `json:"state"`
+	Result    string  `json:"result"`
+	Progress  float64 `json:"progress"`
+	StartedAt string  `json:"started_at"`
+	EndedAt   string  `json:"ended_at"`
+	Humanized struct {
+		Action string   `json:"action"`
+		Input  string   `json:"input"`
+		Output string   `json:"output"`
+		Errors []string `json:"errors"`
+	} `json:"humanized"`
+}
+
+// TaskError is returned when a task finishes with an error result
+type TaskError struct {
+	ID     string
+	Label  string
+	Result string
+	Errors []string
+}
+
+// Error implements the error interface
+func (e *TaskError) Error() string {
+	if len(e.Errors) > 0 {
+		return fmt.Sprintf("task %s [%s] finished with %s: %s", e.ID, e.Label, e.Result, strings.Join(e.Errors, "; "))
+	}
+	return fmt.Sprintf("task %s [%s] finished with %s", e.ID, e.Label, e.Result)
+}
+
+// Done reports whether the task has stopped running, foreman tasks stay pending while paused so only the state is used
+func (t *Task) Done() bool {
+	return t.State == "stopped" || t.State == "paused"
+}
+
+// Err returns a TaskError if the task finished unsuccessfully, paused and cancelled tasks are treated as failed
+func (t *Task) Err() error {
+	if t.Result == "error" || t.Result == "cancelled" || t.State == "paused" {
+		return &TaskError{ID: t.ID, Label: t.Label, Result: t.Result, Errors: t.Humanized.Errors}
+	}
+	return nil
+}
+
+// Wait calls poll every interval until it reports done, returns an error or the context is done.
+// An interval of zero or less polls every 5 seconds
+func Wait(ctx context.Context, interval time.Duration, poll func(context.Context) (bool, error)) error {
+
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		done, err := poll(ctx)
+		if err != nil || done {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// TasksResource returns the typed read only resource for foreman tasks
+func (ci *ConnectionInfo) TasksResource() *ReadOnlyResource[Task] {
+	return NewReadOnlyResource[Task](ci, tasksapi)
+}
+
+// ListTasks returns the tasks matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListTasks(ctx context.Context, search string) ([]Task, error) {
+	return ci.TasksResource().List(ctx, search)
+}
+
+// GetTask returns the task with the id provided
+func (ci *ConnectionInfo) GetTask(ctx context.Context, id string) (*Task, error) {
+	return ci.TasksResource().Get(ctx, id)
+}
+
+// CancelTask cancels the running task with the id provided
+func (ci *ConnectionInfo) CancelTask(ctx context.Context, id string) error {
+	_, err := ci.action(ctx, http.MethodPost, nil, tasksapi, id, "cancel")
+	return err
+}
+
+// WaitTask polls the task every interval until it is done, progress is called after each poll when not nil.
+// A task that finishes with an error is returned along with a TaskError
+func (ci *ConnectionInfo) WaitTask(ctx context.Context, id string, interval time.Duration, progress func(*Task)) (*Task, error) {
+
+	var task *Task
+
+	err := Wait(ctx, interval, func(ctx context.Context) (bool, error) {
+		var err error
+		task, err = ci.GetTask(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		if progress != nil {
+			progress(task)
+		}
+		return task.Done(), nil
+	})
+	if err != nil {
+		return task, err
+	}
+
+	return task, task.Err()
+}
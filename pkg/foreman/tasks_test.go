@@ -0,0 +1,117 @@
+package foreman_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	tasksTimeout = 180
+)
+
+func ExampleConnectionInfo_GetTask() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(`{"id":"a1b2","label":"Actions::RemoteExecution::RunHostsJob","pending":true,"state":"running","result":"pending","progress":0.5}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, tasksTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	task, err := api.GetTask(ctx, "a1b2")
+
+	fmt.Printf("%s %s %v %t %v", task.ID, task.State, task.Progress, task.Done(), err)
+
+	// Output: a1b2 running 0.5 false <nil>
+}
+
+func TestWaitTask(t *testing.T) {
+
+	tt := []struct {
+		name           string
+		final          string
+		expectederr    bool
+		expectedpolls  int
+		expectedresult string
+	}{
+		{name: "success", final: `{"id":"a1b2","pending":false,"state":"stopped","result":"success","progress":1}`, expectedpolls: 3, expectedresult: "success"},
+		{name: "error", final: `{"id":"a1b2","label":"Sync","pending":false,"state":"stopped","result":"error","progress":1,"humanized":{"errors":["connection refused"]}}`, expectederr: true, expectedpolls: 3, expectedresult: "error"},
+		{name: "cancelled", final: `{"id":"a1b2","label":"Sync","pending":false,"state":"stopped","result":"cancelled","progress":1}`, expectederr: true, expectedpolls: 3, expectedresult: "cancelled"},
+		{name: "paused", final: `{"id":"a1b2","label":"Sync","pending":true,"state":"paused","result":"error","progress":0.7}`, expectederr: true, expectedpolls: 3, expectedresult: "error"},
+	}
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, tasksTimeout*time.Second)
+	defer cancel()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			polls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				polls++
+				if polls < 3 {
+					check(rw.Write([]byte(`{"id":"a1b2","pending":true,"state":"running","result":"pending","progress":0.3}`)))
+					return
+				}
+				check(rw.Write([]byte(tc.final)))
+			}))
+			defer server.Close()
+
+			var reported []float64
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+			task, err := api.WaitTask(ctx, "a1b2", time.Millisecond, func(task *foreman.Task) {
+				reported = append(reported, task.Progress)
+			})
+
+			_, isTaskErr := err.(*foreman.TaskError)
+			if tc.expectederr != isTaskErr {
+				t.Fatalf("Test %v task error expected %v, got `%v`", tc.name, tc.expectederr, err)
+			}
+			if tc.expectedresult != task.Result || tc.expectedpolls != polls || len(reported) != polls {
+				t.Errorf("Test %v result should be %v after %v polls, got `%v` after %v polls", tc.name, tc.expectedresult, tc.expectedpolls, task.Result, polls)
+			}
+		})
+	}
+}
+
+func TestWaitContextDone(t *testing.T) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := foreman.Wait(ctx, time.Millisecond, func(context.Context) (bool, error) {
+		return false, nil
+	})
+	if err != context.DeadlineExceeded {
+		t.Errorf("Wait should return the context error, got `%v`", err)
+	}
+}
+
+func TestWaitInterval(t *testing.T) {
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		t.Run(interval.String(), func(t *testing.T) {
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			polls := 0
+			err := foreman.Wait(ctx, interval, func(context.Context) (bool, error) {
+				polls++
+				return false, nil
+			})
+			if err != context.DeadlineExceeded || polls != 1 {
+				t.Errorf("Test %v result should be %v after 1 poll, got `%v` after %v polls", interval, context.DeadlineExceeded, err, polls)
+			}
+		})
+	}
+}
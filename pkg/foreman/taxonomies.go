@@ -0,0 +1,56 @@
+package foreman
+
+import (
+	"context"
+)
+
+const (
+	organizationsapi = "api/organizations"
+	locationsapi     = "api/locations"
+)
+
+// Organization represents a foreman organization
+type Organization struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Location represents a foreman location
+type Location struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// OrganizationsResource returns the typed resource for organizations
+func (ci *ConnectionInfo) OrganizationsResource() *Resource[Organization] {
+	return NewResource[Organization](ci, "organization", organizationsapi)
+}
+
+// LocationsResource returns the typed resource for locations
+func (ci *ConnectionInfo) LocationsResource() *Resource[Location] {
+	return NewResource[Location](ci, "location", locationsapi)
+}
+
+// ListOrganizations returns the organizations matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListOrganizations(ctx context.Context, search string) ([]Organization, error) {
+	return ci.OrganizationsResource().List(ctx, search)
+}
+
+// ResolveOrganization returns the id of the organization with the name or title provided
+func (ci *ConnectionInfo) ResolveOrganization(ctx context.Context, name string) (int, error) {
+	return ci.OrganizationsResource().Resolve(ctx, name)
+}
+
+// ListLocations returns the locations matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListLocations(ctx context.Context, search string) ([]Location, error) {
+	return ci.LocationsResource().List(ctx, search)
+}
+
+// ResolveLocation returns the id of the location with the name or title provided
+func (ci *ConnectionInfo) ResolveLocation(ctx context.Context, name string) (int, error) {
+	return ci.LocationsResource().Resolve(ctx, name)
+}
@@ -0,0 +1,123 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+const (
+	templatesapi = "api/provisioning_templates"
+)
+
+// ProvisioningTemplate represents a foreman provisioning template such as a kickstart or cloud-init file
+type ProvisioningTemplate struct {
+	ID                 int    `json:"id,omitempty"`
+	Name               string `json:"name,omitempty"`
+	Template           string `json:"template,omitempty"`
+	Description        string `json:"description,omitempty"`
+	Snippet            *bool  `json:"snippet,omitempty"`
+	Locked             *bool  `json:"locked,omitempty"`
+	TemplateKindID     int    `json:"template_kind_id,omitempty"`
+	TemplateKindName   string `json:"template_kind_name,omitempty"`
+	AuditComment       string `json:"audit_comment,omitempty"`
+	OperatingSystemIDs []int  `json:"operatingsystem_ids,omitempty"`
+}
+
+// renderResp contains the rendered template returned for a host
+type renderResp struct {
+	Template string `json:"template"`
+}
+
+// ProvisioningTemplatesResource returns the typed resource for provisioning templates
+func (ci *ConnectionInfo) ProvisioningTemplatesResource() *Resource[ProvisioningTemplate] {
+	return NewResource[ProvisioningTemplate](ci, "provisioning_template", templatesapi)
+}
+
+// ListProvisioningTemplates returns the provisioning templates matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListProvisioningTemplates(ctx context.Context, search string) ([]ProvisioningTemplate, error) {
+	return ci.ProvisioningTemplatesResource().List(ctx, search)
+}
+
+// GetProvisioningTemplate returns the provisioning template, including its content, with the id provided
+func (ci *ConnectionInfo) GetProvisioningTemplate(ctx context.Context, id int) (*ProvisioningTemplate, error) {
+	return ci.ProvisioningTemplatesResource().Get(ctx, strconv.Itoa(id))
+}
+
+// CreateProvisioningTemplate creates a new provisioning template
+func (ci *ConnectionInfo) CreateProvisioningTemplate(ctx context.Context, template ProvisioningTemplate) (*ProvisioningTemplate, error) {
+	return ci.ProvisioningTemplatesResource().Create(ctx, template)
+}
+
+// UpdateProvisioningTemplate updates the provisioning template with the id provided
+func (ci *ConnectionInfo) UpdateProvisioningTemplate(ctx context.Context, id int, template ProvisioningTemplate) (*ProvisioningTemplate, error) {
+	return ci.ProvisioningTemplatesResource().Update(ctx, strconv.Itoa(id), template)
+}
+
+// CloneProvisioningTemplate copies the provisioning template with the id provided to a new template called name
+func (ci *ConnectionInfo) CloneProvisioningTemplate(ctx context.Context, id int, name string) (*ProvisioningTemplate, error) {
+	var cloned ProvisioningTemplate
+	req := map[string]ProvisioningTemplate{"provisioning_template": {Name: name}}
+	err := ci.doJSON(ctx, http.MethodPost, ci.apiURL(nil, templatesapi, strconv.Itoa(id), "clone"), req, &cloned)
+	return &cloned, err
+}
+
+// LockProvisioningTemplate locks the provisioning template so it can no longer be edited
+func (ci *ConnectionInfo) LockProvisioningTemplate(ctx context.Context, id int) (*ProvisioningTemplate, error) {
+	return ci.setTemplateLock(ctx, id, true)
+}
+
+// UnlockProvisioningTemplate unlocks the provisioning template so it can be edited
+func (ci *ConnectionInfo) UnlockProvisioningTemplate(ctx context.Context, id int) (*ProvisioningTemplate, error) {
+	return ci.setTemplateLock(ctx, id, false)
+}
+
+// setTemplateLock sets the locked flag explicitly as false would be dropped from a ProvisioningTemplate payload
+func (ci *ConnectionInfo) setTemplateLock(ctx context.Context, id int, locked bool) (*ProvisioningTemplate, error) {
+	var updated ProvisioningTemplate
+	req := map[string]interface{}{"provisioning_template": map[string]bool{"locked": locked}}
+	err := ci.doJSON(ctx, http.MethodPut, ci.apiURL(nil, templatesapi, strconv.Itoa(id)), req, &updated)
+	return &updated, err
+}
+
+// AssociateProvisioningTemplate sets the operating systems the provisioning template is associated with
+func (ci *ConnectionInfo) AssociateProvisioningTemplate(ctx context.Context, id int, operatingSystemIDs []int) (*ProvisioningTemplate, error) {
+	var updated ProvisioningTemplate
+	req := map[string]interface{}{"provisioning_template": map[string][]int{"operatingsystem_ids": operatingSystemIDs}}
+	err := ci.doJSON(ctx, http.MethodPut, ci.apiURL(nil, templatesapi, strconv.Itoa(id)), req, &updated)
+	return &updated, err
+}
+
+// RenderProvisioningTemplate returns a preview of the provisioning template rendered for the host provided
+func (ci *ConnectionInfo) RenderProvisioningTemplate(ctx context.Context, id int, hostID int) (string, error) {
+
+	data, err := json.Marshal(map[string]int{"host_id": hostID})
+	if err != nil {
+		return "", err
+	}
+
+	body, err := ci.doRequest(ctx, http.MethodPost, ci.apiURL(nil, templatesapi, strconv.Itoa(id), "render"), data)
+	if err != nil {
+		return "", err
+	}
+
+	var rendered renderResp
+	if json.Unmarshal(body, &rendered) == nil && rendered.Template != "" {
+		return rendered.Template, nil
+	}
+
+	return string(body), nil
+}
+
+// RenderHostTemplate returns the template of the kind provided (provision, PXELinux, user_data...) rendered for a host
+func (ci *ConnectionInfo) RenderHostTemplate(ctx context.Context, hostname string, kind string) (string, error) {
+	var rendered renderResp
+	err := ci.doJSON(ctx, http.MethodGet, ci.apiURL(nil, hostsapi, hostname, "template", kind), nil, &rendered)
+	return rendered.Template, err
+}
+
+// ResolveProvisioningTemplate returns the id of the provisioning template with the name provided
+func (ci *ConnectionInfo) ResolveProvisioningTemplate(ctx context.Context, name string) (int, error) {
+	return ci.ProvisioningTemplatesResource().Resolve(ctx, name)
+}
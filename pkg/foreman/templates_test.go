@@ -0,0 +1,115 @@
+package foreman_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	templatesTimeout = 180
+)
+
+func ExampleConnectionInfo_GetProvisioningTemplate() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(`{"id":12,"name":"Kickstart default","template":"install\nreboot\n","locked":true}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, templatesTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	template, err := api.GetProvisioningTemplate(ctx, 12)
+
+	fmt.Printf("%s %t %q %v", template.Name, *template.Locked, template.Template, err)
+
+	// Output: Kickstart default true "install\nreboot\n" <nil>
+}
+
+func TestTemplateRequests(t *testing.T) {
+
+	var method, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		data, _ := ioutil.ReadAll(req.Body)
+		method, path, body = req.Method, req.URL.Path, string(data)
+		check(rw.Write([]byte(`{"id":12,"template":"rendered"}`)))
+	}))
+	defer server.Close()
+
+	tt := []struct {
+		name         string
+		call         func(*foreman.ConnectionInfo, context.Context) error
+		expectedpath string
+		expectedbody string
+		method       string
+	}{
+		{
+			name: "unlock",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) error {
+				_, err := ci.UnlockProvisioningTemplate(ctx, 12)
+				return err
+			},
+			method: http.MethodPut, expectedpath: "/api/provisioning_templates/12", expectedbody: `{"provisioning_template":{"locked":false}}`,
+		},
+		{
+			name: "clone",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) error {
+				_, err := ci.CloneProvisioningTemplate(ctx, 12, "Kickstart copy")
+				return err
+			},
+			method: http.MethodPost, expectedpath: "/api/provisioning_templates/12/clone", expectedbody: `{"provisioning_template":{"name":"Kickstart copy"}}`,
+		},
+		{
+			name: "associate",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) error {
+				_, err := ci.AssociateProvisioningTemplate(ctx, 12, []int{1, 2})
+				return err
+			},
+			method: http.MethodPut, expectedpath: "/api/provisioning_templates/12", expectedbody: `{"provisioning_template":{"operatingsystem_ids":[1,2]}}`,
+		},
+		{
+			name: "render preview",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) error {
+				_, err := ci.RenderProvisioningTemplate(ctx, 12, 7)
+				return err
+			},
+			method: http.MethodPost, expectedpath: "/api/provisioning_templates/12/render", expectedbody: `{"host_id":7}`,
+		},
+		{
+			name: "render host",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) error {
+				_, err := ci.RenderHostTemplate(ctx, "dev99", "provision")
+				return err
+			},
+			method: http.MethodGet, expectedpath: "/api/hosts/dev99/template/provision", expectedbody: "",
+		},
+	}
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, templatesTimeout*time.Second)
+	defer cancel()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+			err := tc.call(&api, ctx)
+			if err != nil {
+				t.Fatalf("Could not read response %v correctly", err)
+			}
+			if tc.method != method || tc.expectedpath != path || tc.expectedbody != body {
+				t.Errorf("Test %v request should be %v %v %v, got `%v %v %v`", tc.name, tc.method, tc.expectedpath, tc.expectedbody, method, path, body)
+			}
+			log.Printf("Request: %s %s %s", method, path, body)
+		})
+	}
+}
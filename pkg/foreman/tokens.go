@@ -0,0 +1,52 @@
+package foreman
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// PersonalAccessToken represents an api token of a user, the value is only returned when it is created
+type PersonalAccessToken struct {
+	ID         int    `json:"id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	UserID     int    `json:"user_id,omitempty"`
+	ExpiresAt  string `json:"expires_at,omitempty"`
+	LastUsedAt string `json:"last_used_at,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	Active     bool   `json:"active,omitempty"`
+	Revoked    bool   `json:"revoked,omitempty"`
+	TokenValue string `json:"token_value,omitempty"`
+}
+
+// PersonalAccessTokensResource returns the typed resource for the personal access tokens of the user
+func (ci *ConnectionInfo) PersonalAccessTokensResource(userID int) *Resource[PersonalAccessToken] {
+	return NewResource[PersonalAccessToken](ci, "personal_access_token", usersapi, strconv.Itoa(userID), "personal_access_tokens")
+}
+
+// ListPersonalAccessTokens returns the personal access tokens of the user
+func (ci *ConnectionInfo) ListPersonalAccessTokens(ctx context.Context, userID int) ([]PersonalAccessToken, error) {
+	return ci.PersonalAccessTokensResource(userID).List(ctx, "")
+}
+
+// GetPersonalAccessToken returns the personal access token of the user with the id provided
+func (ci *ConnectionInfo) GetPersonalAccessToken(ctx context.Context, userID int, id int) (*PersonalAccessToken, error) {
+	return ci.PersonalAccessTokensResource(userID).Get(ctx, strconv.Itoa(id))
+}
+
+// CreatePersonalAccessToken mints a new token for the user, a zero expiresAt creates a token that never expires.
+// The returned TokenValue cannot be retrieved again
+func (ci *ConnectionInfo) CreatePersonalAccessToken(ctx context.Context, userID int, name string, expiresAt time.Time) (*PersonalAccessToken, error) {
+
+	token := PersonalAccessToken{Name: name}
+	if !expiresAt.IsZero() {
+		token.ExpiresAt = expiresAt.UTC().Format(time.RFC3339)
+	}
+
+	return ci.PersonalAccessTokensResource(userID).Create(ctx, token)
+}
+
+// RevokePersonalAccessToken revokes the personal access token of the user with the id provided
+func (ci *ConnectionInfo) RevokePersonalAccessToken(ctx context.Context, userID int, id int) error {
+	return ci.PersonalAccessTokensResource(userID).Delete(ctx, strconv.Itoa(id))
+}
@@ -0,0 +1,96 @@
+package foreman_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	tokensTimeout = 180
+)
+
+func ExampleConnectionInfo_CreatePersonalAccessToken() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(`{"id":12,"name":"ci","user_id":4,"expires_at":"2020-04-01T00:00:00Z","active":true,"token_value":"s3cr3t"}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, tokensTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	token, err := api.CreatePersonalAccessToken(ctx, 4, "ci", time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC))
+
+	fmt.Printf("%d %s %s %v", token.ID, token.Name, token.TokenValue, err)
+
+	// Output: 12 ci s3cr3t <nil>
+}
+
+func TestTokenRequests(t *testing.T) {
+
+	var method, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		data, _ := ioutil.ReadAll(req.Body)
+		method, path, body = req.Method, req.URL.Path, string(data)
+		check(rw.Write([]byte(`{"id":12,"name":"ci"}`)))
+	}))
+	defer server.Close()
+
+	tt := []struct {
+		name         string
+		call         func(*foreman.ConnectionInfo, context.Context) error
+		method       string
+		expectedpath string
+		expectedbody string
+	}{
+		{
+			name: "create with expiry",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) error {
+				_, err := ci.CreatePersonalAccessToken(ctx, 4, "ci", time.Date(2020, 4, 1, 1, 0, 0, 0, time.FixedZone("IST", 3600)))
+				return err
+			},
+			method: http.MethodPost, expectedpath: "/api/users/4/personal_access_tokens", expectedbody: `{"personal_access_token":{"name":"ci","expires_at":"2020-04-01T00:00:00Z"}}`,
+		},
+		{
+			name: "create without expiry",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) error {
+				_, err := ci.CreatePersonalAccessToken(ctx, 4, "ci", time.Time{})
+				return err
+			},
+			method: http.MethodPost, expectedpath: "/api/users/4/personal_access_tokens", expectedbody: `{"personal_access_token":{"name":"ci"}}`,
+		},
+		{
+			name: "revoke",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) error {
+				return ci.RevokePersonalAccessToken(ctx, 4, 12)
+			},
+			method: http.MethodDelete, expectedpath: "/api/users/4/personal_access_tokens/12", expectedbody: "",
+		},
+	}
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, tokensTimeout*time.Second)
+	defer cancel()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+			err := tc.call(&api, ctx)
+			if err != nil {
+				t.Fatalf("Could not read response %v correctly", err)
+			}
+			if tc.method != method || tc.expectedpath != path || tc.expectedbody != body {
+				t.Errorf("Test %v request should be %v %v %v, got `%v %v %v`", tc.name, tc.method, tc.expectedpath, tc.expectedbody, method, path, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package foreman
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+const (
+	usergroupsapi = "api/usergroups"
+)
+
+// Usergroup represents a foreman group of users and nested usergroups.
+// UserIDs, UsergroupIDs and RoleIDs replace the members when the usergroup is updated, foreman returns the members as Users, Usergroups and Roles
+type Usergroup struct {
+	ID           int               `json:"id,omitempty"`
+	Name         string            `json:"name,omitempty"`
+	Admin        *bool             `json:"admin,omitempty"`
+	UserIDs      []int             `json:"user_ids,omitempty"`
+	UsergroupIDs []int             `json:"usergroup_ids,omitempty"`
+	RoleIDs      []int             `json:"role_ids,omitempty"`
+	Users        []UsergroupMember `json:"users,omitempty"`
+	Usergroups   []UsergroupMember `json:"usergroups,omitempty"`
+	Roles        []UsergroupMember `json:"roles,omitempty"`
+}
+
+// UsergroupMember is a user, usergroup or role of a usergroup as foreman returns it
+type UsergroupMember struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Login string `json:"login,omitempty"`
+}
+
+// ExternalUsergroup maps a group of an external authentication source, such as LDAP, to a usergroup
+type ExternalUsergroup struct {
+	ID           int    `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	AuthSourceID int    `json:"auth_source_id,omitempty"`
+}
+
+// UsergroupsResource returns the typed resource for usergroups
+func (ci *ConnectionInfo) UsergroupsResource() *Resource[Usergroup] {
+	return NewResource[Usergroup](ci, "usergroup", usergroupsapi)
+}
+
+// ListUsergroups returns the usergroups matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListUsergroups(ctx context.Context, search string) ([]Usergroup, error) {
+	return ci.UsergroupsResource().List(ctx, search)
+}
+
+// GetUsergroup returns the usergroup with the id provided
+func (ci *ConnectionInfo) GetUsergroup(ctx context.Context, id int) (*Usergroup, error) {
+	return ci.UsergroupsResource().Get(ctx, strconv.Itoa(id))
+}
+
+// CreateUsergroup creates a new usergroup
+func (ci *ConnectionInfo) CreateUsergroup(ctx context.Context, group Usergroup) (*Usergroup, error) {
+	return ci.UsergroupsResource().Create(ctx, group)
+}
+
+// UpdateUsergroup updates the usergroup with the id provided
+func (ci *ConnectionInfo) UpdateUsergroup(ctx context.Context, id int, group Usergroup) (*Usergroup, error) {
+	return ci.UsergroupsResource().Update(ctx, strconv.Itoa(id), group)
+}
+
+// DeleteUsergroup deletes the usergroup with the id provided
+func (ci *ConnectionInfo) DeleteUsergroup(ctx context.Context, id int) error {
+	return ci.UsergroupsResource().Delete(ctx, strconv.Itoa(id))
+}
+
+// AddUsergroupMembers adds the users and roles to those the usergroup already has, unlike UpdateUsergroup which replaces them
+func (ci *ConnectionInfo) AddUsergroupMembers(ctx context.Context, id int, userIDs []int, roleIDs []int) (*Usergroup, error) {
+
+	group, err := ci.GetUsergroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	update := Usergroup{
+		UserIDs: mergeIDs(memberIDs(group.UserIDs, group.Users), userIDs),
+		RoleIDs: mergeIDs(memberIDs(group.RoleIDs, group.Roles), roleIDs),
+	}
+
+	return ci.UpdateUsergroup(ctx, id, update)
+}
+
+// memberIDs returns the ids of the members, whichever way foreman returned them
+func memberIDs(ids []int, members []UsergroupMember) []int {
+	for _, m := range members {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
+
+// mergeIDs returns the ids of both lists without duplicates, in the order they first appear
+func mergeIDs(a []int, b []int) []int {
+
+	seen := map[int]bool{}
+	var ids []int
+	for _, id := range append(append([]int{}, a...), b...) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// ResolveUsergroup returns the id of the usergroup with the name provided
+func (ci *ConnectionInfo) ResolveUsergroup(ctx context.Context, name string) (int, error) {
+	return ci.UsergroupsResource().Resolve(ctx, name)
+}
+
+// ExternalUsergroupsResource returns the typed resource for the external groups mapped to the usergroup
+func (ci *ConnectionInfo) ExternalUsergroupsResource(usergroupID int) *Resource[ExternalUsergroup] {
+	return NewResource[ExternalUsergroup](ci, "external_usergroup", usergroupsapi, strconv.Itoa(usergroupID), "external_usergroups")
+}
+
+// ListExternalUsergroups returns the external groups mapped to the usergroup
+func (ci *ConnectionInfo) ListExternalUsergroups(ctx context.Context, usergroupID int) ([]ExternalUsergroup, error) {
+	return ci.ExternalUsergroupsResource(usergroupID).List(ctx, "")
+}
+
+// CreateExternalUsergroup maps an external group to the usergroup
+func (ci *ConnectionInfo) CreateExternalUsergroup(ctx context.Context, usergroupID int, group ExternalUsergroup) (*ExternalUsergroup, error) {
+	return ci.ExternalUsergroupsResource(usergroupID).Create(ctx, group)
+}
+
+// DeleteExternalUsergroup removes the external group mapping from the usergroup
+func (ci *ConnectionInfo) DeleteExternalUsergroup(ctx context.Context, usergroupID int, id int) error {
+	return ci.ExternalUsergroupsResource(usergroupID).Delete(ctx, strconv.Itoa(id))
+}
+
+// RefreshExternalUsergroup synchronises the usergroup members with the external group
+func (ci *ConnectionInfo) RefreshExternalUsergroup(ctx context.Context, usergroupID int, id int) error {
+	return ci.doJSON(ctx, http.MethodPut, ci.apiURL(nil, usergroupsapi, strconv.Itoa(usergroupID), "external_usergroups", strconv.Itoa(id), "refresh"), nil, nil)
+}
@@ -0,0 +1,127 @@
+package foreman_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	usergroupsTimeout = 180
+)
+
+func ExampleConnectionInfo_ListExternalUsergroups() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(`{"total":1,"subtotal":1,"page":1,"per_page":100,"results":[{"id":1,"name":"cn=devs","auth_source_id":2}]}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, usergroupsTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	groups, err := api.ListExternalUsergroups(ctx, 3)
+
+	fmt.Printf("%s %d %v", groups[0].Name, groups[0].AuthSourceID, err)
+
+	// Output: cn=devs 2 <nil>
+}
+
+func TestExternalUsergroupRequests(t *testing.T) {
+
+	var method, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		data, _ := ioutil.ReadAll(req.Body)
+		method, path, body = req.Method, req.URL.Path, string(data)
+		check(rw.Write([]byte(`{"id":1,"name":"cn=devs","auth_source_id":2}`)))
+	}))
+	defer server.Close()
+
+	tt := []struct {
+		name         string
+		call         func(*foreman.ConnectionInfo, context.Context) error
+		method       string
+		expectedpath string
+		expectedbody string
+	}{
+		{
+			name: "create",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) error {
+				_, err := ci.CreateExternalUsergroup(ctx, 3, foreman.ExternalUsergroup{Name: "cn=devs", AuthSourceID: 2})
+				return err
+			},
+			method: http.MethodPost, expectedpath: "/api/usergroups/3/external_usergroups", expectedbody: `{"external_usergroup":{"name":"cn=devs","auth_source_id":2}}`,
+		},
+		{
+			name: "refresh",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) error {
+				return ci.RefreshExternalUsergroup(ctx, 3, 1)
+			},
+			method: http.MethodPut, expectedpath: "/api/usergroups/3/external_usergroups/1/refresh", expectedbody: "",
+		},
+		{
+			name: "delete",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) error {
+				return ci.DeleteExternalUsergroup(ctx, 3, 1)
+			},
+			method: http.MethodDelete, expectedpath: "/api/usergroups/3/external_usergroups/1", expectedbody: "",
+		},
+	}
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, usergroupsTimeout*time.Second)
+	defer cancel()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+			err := tc.call(&api, ctx)
+			if err != nil {
+				t.Fatalf("Could not read response %v correctly", err)
+			}
+			if tc.method != method || tc.expectedpath != path || tc.expectedbody != body {
+				t.Errorf("Test %v request should be %v %v %v, got `%v %v %v`", tc.name, tc.method, tc.expectedpath, tc.expectedbody, method, path, body)
+			}
+			log.Printf("Request: %s %s %s", method, path, body)
+		})
+	}
+}
+
+func TestAddUsergroupMembers(t *testing.T) {
+
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodGet {
+			check(rw.Write([]byte(`{"id":3,"name":"devs","users":[{"id":1,"login":"admin"},{"id":4,"login":"jdoe"}],"roles":[{"id":2,"name":"Viewer"}]}`)))
+			return
+		}
+		data, _ := ioutil.ReadAll(req.Body)
+		body = string(data)
+		check(rw.Write([]byte(`{"id":3,"name":"devs"}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, usergroupsTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	_, err := api.AddUsergroupMembers(ctx, 3, []int{4, 7}, []int{5})
+	if err != nil {
+		t.Fatalf("Could not read response %v correctly", err)
+	}
+
+	expectedbody := `{"usergroup":{"user_ids":[1,4,7],"role_ids":[2,5]}}`
+	if body != expectedbody {
+		t.Errorf("Test add members request should be %v, got `%v`", expectedbody, body)
+	}
+}
@@ -0,0 +1,122 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+const (
+	usersapi = "api/users"
+)
+
+// User represents a foreman user account
+type User struct {
+	ID                    int    `json:"id,omitempty"`
+	Login                 string `json:"login,omitempty"`
+	Firstname             string `json:"firstname,omitempty"`
+	Lastname              string `json:"lastname,omitempty"`
+	Mail                  string `json:"mail,omitempty"`
+	Admin                 *bool  `json:"admin,omitempty"`
+	Password              string `json:"password,omitempty"`
+	AuthSourceID          int    `json:"auth_source_id,omitempty"`
+	DefaultOrganizationID int    `json:"default_organization_id,omitempty"`
+	DefaultLocationID     int    `json:"default_location_id,omitempty"`
+	RoleIDs               []int  `json:"role_ids,omitempty"`
+	OrganizationIDs       []int  `json:"organization_ids,omitempty"`
+	LocationIDs           []int  `json:"location_ids,omitempty"`
+
+	Roles         []Role         `json:"roles,omitempty"`
+	Organizations []Organization `json:"organizations,omitempty"`
+	Locations     []Location     `json:"locations,omitempty"`
+}
+
+// UsersResource returns the typed resource for users
+func (ci *ConnectionInfo) UsersResource() *Resource[User] {
+	return NewResource[User](ci, "user", usersapi)
+}
+
+// ListUsers returns the users matching the search, an empty search returns all
+func (ci *ConnectionInfo) ListUsers(ctx context.Context, search string) ([]User, error) {
+	return ci.UsersResource().List(ctx, search)
+}
+
+// GetUser returns the user with the id provided
+func (ci *ConnectionInfo) GetUser(ctx context.Context, id int) (*User, error) {
+	return ci.UsersResource().Get(ctx, strconv.Itoa(id))
+}
+
+// CreateUser creates a new user
+func (ci *ConnectionInfo) CreateUser(ctx context.Context, user User) (*User, error) {
+	return ci.UsersResource().Create(ctx, user)
+}
+
+// UpdateUser updates the user with the id provided
+func (ci *ConnectionInfo) UpdateUser(ctx context.Context, id int, user User) (*User, error) {
+	return ci.UsersResource().Update(ctx, strconv.Itoa(id), user)
+}
+
+// DeleteUser deletes the user with the id provided
+func (ci *ConnectionInfo) DeleteUser(ctx context.Context, id int) error {
+	return ci.UsersResource().Delete(ctx, strconv.Itoa(id))
+}
+
+// AssignUserRoles replaces the roles of the user with the ones provided
+func (ci *ConnectionInfo) AssignUserRoles(ctx context.Context, id int, roleIDs []int) (*User, error) {
+	return ci.UpdateUser(ctx, id, User{RoleIDs: roleIDs})
+}
+
+// AssignUserTaxonomies replaces the organizations and locations of the user with the ones provided
+func (ci *ConnectionInfo) AssignUserTaxonomies(ctx context.Context, id int, organizationIDs []int, locationIDs []int) (*User, error) {
+	return ci.UpdateUser(ctx, id, User{OrganizationIDs: organizationIDs, LocationIDs: locationIDs})
+}
+
+// AddUserAssignments adds the roles, organizations and locations to those the user already has,
+// unlike AssignUserRoles and AssignUserTaxonomies which replace them
+func (ci *ConnectionInfo) AddUserAssignments(ctx context.Context, id int, roleIDs []int, organizationIDs []int, locationIDs []int) (*User, error) {
+
+	user, err := ci.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// foreman returns the assignments of a user as objects rather than ids
+	roles, organizations, locations := user.RoleIDs, user.OrganizationIDs, user.LocationIDs
+	for _, r := range user.Roles {
+		roles = append(roles, r.ID)
+	}
+	for _, o := range user.Organizations {
+		organizations = append(organizations, o.ID)
+	}
+	for _, l := range user.Locations {
+		locations = append(locations, l.ID)
+	}
+
+	update := User{
+		RoleIDs:         mergeIDs(roles, roleIDs),
+		OrganizationIDs: mergeIDs(organizations, organizationIDs),
+		LocationIDs:     mergeIDs(locations, locationIDs),
+	}
+
+	return ci.UpdateUser(ctx, id, update)
+}
+
+// ResolveUser returns the id of the user with the login provided, numeric logins are treated as ids
+func (ci *ConnectionInfo) ResolveUser(ctx context.Context, login string) (int, error) {
+
+	if id, err := strconv.Atoi(login); err == nil {
+		return id, nil
+	}
+
+	users, err := ci.ListUsers(ctx, fmt.Sprintf("login = %q", login))
+	if err != nil {
+		return 0, err
+	}
+	for _, u := range users {
+		if u.Login == login {
+			return u.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("user [%s] %w", login, ErrNotFound)
+}
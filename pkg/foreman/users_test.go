@@ -0,0 +1,148 @@
+package foreman_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	usersTimeout = 180
+)
+
+func ExampleConnectionInfo_CreateUser() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		log.Printf("Request: %s", body)
+		check(rw.Write([]byte(`{"id":4,"login":"jdoe","mail":"jdoe@example.com","admin":false}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, usersTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	user, err := api.CreateUser(ctx, foreman.User{Login: "jdoe", Mail: "jdoe@example.com", AuthSourceID: 1, RoleIDs: []int{2}})
+
+	fmt.Printf("%d %s %v", user.ID, user.Login, err)
+
+	// Output: 4 jdoe <nil>
+}
+
+func TestAssignUser(t *testing.T) {
+
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		data, _ := ioutil.ReadAll(req.Body)
+		body = string(data)
+		check(rw.Write([]byte(`{"id":4,"login":"jdoe"}`)))
+	}))
+	defer server.Close()
+
+	tt := []struct {
+		name           string
+		call           func(*foreman.ConnectionInfo, context.Context) error
+		expectedresult string
+	}{
+		{
+			name: "roles",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) error {
+				_, err := ci.AssignUserRoles(ctx, 4, []int{1, 2})
+				return err
+			},
+			expectedresult: `{"user":{"role_ids":[1,2]}}`,
+		},
+		{
+			name: "taxonomies",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) error {
+				_, err := ci.AssignUserTaxonomies(ctx, 4, []int{9}, []int{15})
+				return err
+			},
+			expectedresult: `{"user":{"organization_ids":[9],"location_ids":[15]}}`,
+		},
+		{
+			name: "revoke admin",
+			call: func(ci *foreman.ConnectionInfo, ctx context.Context) error {
+				admin := false
+				_, err := ci.UpdateUser(ctx, 4, foreman.User{Admin: &admin})
+				return err
+			},
+			expectedresult: `{"user":{"admin":false}}`,
+		},
+	}
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, usersTimeout*time.Second)
+	defer cancel()
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+			err := tc.call(&api, ctx)
+			if err != nil {
+				t.Fatalf("Could not read response %v correctly", err)
+			}
+			if tc.expectedresult != body {
+				t.Errorf("Test %v result should be %v, got  `%v`", tc.name, tc.expectedresult, body)
+			}
+		})
+	}
+}
+
+func TestResolveUserNotFound(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		check(rw.Write([]byte(`{"total":1,"subtotal":0,"page":1,"per_page":100,"results":[]}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, usersTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	_, err := api.ResolveUser(ctx, "jdoe")
+	if !errors.Is(err, foreman.ErrNotFound) {
+		t.Errorf("error should wrap ErrNotFound, got `%v`", err)
+	}
+}
+
+func TestAddUserAssignments(t *testing.T) {
+
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodGet {
+			check(rw.Write([]byte(`{"id":4,"login":"jdoe","roles":[{"id":2,"name":"Viewer"}],"organizations":[{"id":9,"name":"ACME"}],"locations":[]}`)))
+			return
+		}
+		data, _ := ioutil.ReadAll(req.Body)
+		body = string(data)
+		check(rw.Write([]byte(`{"id":4,"login":"jdoe"}`)))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, usersTimeout*time.Second)
+	defer cancel()
+
+	api := foreman.ConnectionInfo{Username: "test", Password: "test", BaseURL: server.URL, Client: server.Client()}
+	_, err := api.AddUserAssignments(ctx, 4, []int{2, 5}, []int{9}, []int{15})
+	if err != nil {
+		t.Fatalf("Could not read response %v correctly", err)
+	}
+
+	expectedbody := `{"user":{"role_ids":[2,5],"organization_ids":[9],"location_ids":[15]}}`
+	if body != expectedbody {
+		t.Errorf("Test add assignments request should be %v, got `%v`", expectedbody, body)
+	}
+}
@@ -18,6 +18,9 @@ const (
 	#  Usage:                                                                                    #
 	#      ./foreman-client create -name=mytestenv -size=i3.4xlarge -group=1 -profile=2          #
 	#                                                                                            #
+	#  Optional:                                                                                 #
+	#      -os="CentOS 7.9" -arch=x86_64 -medium=CentOS-mirror -ptable="Kickstart default"       #
+	#                                                                                            #
 	##############################################################################################
 	`
 
@@ -85,6 +88,10 @@ func checkFlags(ci *ConnectionInfo) error {
 	createSizePtr := createCommand.String("size", "", "size of instance to create. (Required)")
 	createHostGroupPtr := createCommand.Int("group", 0, "hostgroup_id to use. (Required)")
 	createHostProfilePtr := createCommand.String("profile", "0", "compute_profile_id to use. (Required)")
+	createOSPtr := createCommand.String("os", "", "operating system name or id to use.")
+	createArchPtr := createCommand.String("arch", "", "architecture name or id to use.")
+	createMediumPtr := createCommand.String("medium", "", "installation medium name or id to use.")
+	createPtablePtr := createCommand.String("ptable", "", "partition table name or id to use.")
 
 	deleteCommand := flag.NewFlagSet(deleteArg, flag.ExitOnError)
 	deleteNamePtr := deleteCommand.String("name", "", "name of instance to delete. (Required)")
@@ -137,6 +144,11 @@ func checkFlags(ci *ConnectionInfo) error {
 			msg := "compute_profile_id needs to be provided"
 			return errors.New(msg)
 		}
+
+		ci.OperatingSystem = *createOSPtr
+		ci.Architecture = *createArchPtr
+		ci.Medium = *createMediumPtr
+		ci.PartitionTable = *createPtablePtr
 	}
 	if deleteCommand.Parsed() {
 		if *deleteNamePtr == "" {
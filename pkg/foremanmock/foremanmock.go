@@ -0,0 +1,1613 @@
+/*
+Package foremanmock provides a mock implementation of the foreman services so code depending on them can be unit tested without http.
+
+Set the function field of each method the code under test calls, methods without a function return zero values and an error wrapping ErrNotMocked.
+*/
+package foremanmock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+// ErrNotMocked is wrapped by the error returned when a method is called without its function set
+var ErrNotMocked = errors.New("not mocked")
+
+var _ foreman.Client = (*Client)(nil)
+
+// Client is a mock foreman.Client, each method calls the function field of the same name.
+// Methods whose function is not set return zero values and an error
+type Client struct {
+	// StatusService
+	CheckStatusFunc func(context.Context) (bool, string, error)
+
+	// HostsService
+	CheckHostFunc              func(context.Context) (bool, string, error)
+	CreateHostFunc             func(context.Context) (bool, string, error)
+	DeleteHostFunc             func(context.Context) (bool, string, error)
+	RebuildHostFunc            func(context.Context, bool) error
+	CancelBuildFunc            func(context.Context) error
+	PowerHostFunc              func(context.Context, string) error
+	GetBuildStatusFunc         func(context.Context) (*foreman.BuildStatus, error)
+	RebuildConfigFunc          func(context.Context, ...string) error
+	GetVMComputeAttributesFunc func(context.Context) (map[string]interface{}, error)
+	ResolveHostFunc            func(context.Context, string) (int, error)
+	GetHostByFunc              func(context.Context, string) (*foreman.Host, error)
+
+	// BulkHostsService
+	BulkDestroyHostsFunc      func(context.Context, string) ([]foreman.BulkHostResult, error)
+	BulkReassignHostgroupFunc func(context.Context, string, int) ([]foreman.BulkHostResult, error)
+	BulkChangeOwnerFunc       func(context.Context, string, int, string) ([]foreman.BulkHostResult, error)
+	BulkChangeEnvironmentFunc func(context.Context, string, int) ([]foreman.BulkHostResult, error)
+	BulkBuildFunc             func(context.Context, string, bool) ([]foreman.BulkHostResult, error)
+	BulkPowerFunc             func(context.Context, string, string) ([]foreman.BulkHostResult, error)
+
+	// HostgroupsService
+	ResolveHostgroupFunc func(context.Context, string) (int, error)
+
+	// TaxonomiesService
+	ListOrganizationsFunc   func(context.Context, string) ([]foreman.Organization, error)
+	ResolveOrganizationFunc func(context.Context, string) (int, error)
+	ListLocationsFunc       func(context.Context, string) ([]foreman.Location, error)
+	ResolveLocationFunc     func(context.Context, string) (int, error)
+
+	// OperatingSystemsService
+	ListOperatingSystemsFunc   func(context.Context, string) ([]foreman.OperatingSystem, error)
+	GetOperatingSystemFunc     func(context.Context, int) (*foreman.OperatingSystem, error)
+	CreateOperatingSystemFunc  func(context.Context, foreman.OperatingSystem) (*foreman.OperatingSystem, error)
+	UpdateOperatingSystemFunc  func(context.Context, int, foreman.OperatingSystem) (*foreman.OperatingSystem, error)
+	ResolveOperatingSystemFunc func(context.Context, string) (int, error)
+
+	// ArchitecturesService
+	ListArchitecturesFunc   func(context.Context, string) ([]foreman.Architecture, error)
+	GetArchitectureFunc     func(context.Context, int) (*foreman.Architecture, error)
+	CreateArchitectureFunc  func(context.Context, foreman.Architecture) (*foreman.Architecture, error)
+	UpdateArchitectureFunc  func(context.Context, int, foreman.Architecture) (*foreman.Architecture, error)
+	ResolveArchitectureFunc func(context.Context, string) (int, error)
+
+	// MediaService
+	ListMediaFunc     func(context.Context, string) ([]foreman.Medium, error)
+	GetMediumFunc     func(context.Context, int) (*foreman.Medium, error)
+	CreateMediumFunc  func(context.Context, foreman.Medium) (*foreman.Medium, error)
+	UpdateMediumFunc  func(context.Context, int, foreman.Medium) (*foreman.Medium, error)
+	ResolveMediumFunc func(context.Context, string) (int, error)
+
+	// PartitionTablesService
+	ListPartitionTablesFunc   func(context.Context, string) ([]foreman.PartitionTable, error)
+	GetPartitionTableFunc     func(context.Context, int) (*foreman.PartitionTable, error)
+	CreatePartitionTableFunc  func(context.Context, foreman.PartitionTable) (*foreman.PartitionTable, error)
+	UpdatePartitionTableFunc  func(context.Context, int, foreman.PartitionTable) (*foreman.PartitionTable, error)
+	ResolvePartitionTableFunc func(context.Context, string) (int, error)
+
+	// ProvisioningTemplatesService
+	ListProvisioningTemplatesFunc     func(context.Context, string) ([]foreman.ProvisioningTemplate, error)
+	GetProvisioningTemplateFunc       func(context.Context, int) (*foreman.ProvisioningTemplate, error)
+	CreateProvisioningTemplateFunc    func(context.Context, foreman.ProvisioningTemplate) (*foreman.ProvisioningTemplate, error)
+	UpdateProvisioningTemplateFunc    func(context.Context, int, foreman.ProvisioningTemplate) (*foreman.ProvisioningTemplate, error)
+	CloneProvisioningTemplateFunc     func(context.Context, int, string) (*foreman.ProvisioningTemplate, error)
+	LockProvisioningTemplateFunc      func(context.Context, int) (*foreman.ProvisioningTemplate, error)
+	UnlockProvisioningTemplateFunc    func(context.Context, int) (*foreman.ProvisioningTemplate, error)
+	AssociateProvisioningTemplateFunc func(context.Context, int, []int) (*foreman.ProvisioningTemplate, error)
+	RenderProvisioningTemplateFunc    func(context.Context, int, int) (string, error)
+	RenderHostTemplateFunc            func(context.Context, string, string) (string, error)
+	ResolveProvisioningTemplateFunc   func(context.Context, string) (int, error)
+
+	// FactsService
+	GetHostFactsFunc      func(context.Context) (map[string]string, error)
+	ListFactValuesFunc    func(context.Context, string) (foreman.FactValues, error)
+	SearchHostsByFactFunc func(context.Context, string, string) ([]string, error)
+
+	// ConfigReportsService
+	ListConfigReportsFunc   func(context.Context, string) ([]foreman.ConfigReport, error)
+	GetConfigReportFunc     func(context.Context, int) (*foreman.ConfigReport, error)
+	GetLastConfigReportFunc func(context.Context) (*foreman.ConfigReport, error)
+
+	// JobsService
+	ListJobTemplatesFunc       func(context.Context, string) ([]foreman.JobTemplate, error)
+	ResolveJobTemplateFunc     func(context.Context, string) (int, error)
+	CreateJobInvocationFunc    func(context.Context, int, string, map[string]string) (*foreman.JobInvocation, error)
+	GetJobInvocationFunc       func(context.Context, int) (*foreman.JobInvocation, error)
+	WaitJobInvocationFunc      func(context.Context, int, time.Duration) (*foreman.JobInvocation, error)
+	ListJobInvocationHostsFunc func(context.Context, int) ([]foreman.JobInvocationHost, error)
+	GetJobOutputFunc           func(context.Context, int, int) (*foreman.JobOutput, error)
+
+	// TasksService
+	ListTasksFunc  func(context.Context, string) ([]foreman.Task, error)
+	GetTaskFunc    func(context.Context, string) (*foreman.Task, error)
+	CancelTaskFunc func(context.Context, string) error
+	WaitTaskFunc   func(context.Context, string, time.Duration, func(*foreman.Task)) (*foreman.Task, error)
+
+	// AuditsService
+	ListAuditsFunc   func(context.Context, string) ([]foreman.Audit, error)
+	FilterAuditsFunc func(context.Context, foreman.AuditFilter) ([]foreman.Audit, error)
+
+	// UsersService
+	ListUsersFunc            func(context.Context, string) ([]foreman.User, error)
+	GetUserFunc              func(context.Context, int) (*foreman.User, error)
+	CreateUserFunc           func(context.Context, foreman.User) (*foreman.User, error)
+	UpdateUserFunc           func(context.Context, int, foreman.User) (*foreman.User, error)
+	DeleteUserFunc           func(context.Context, int) error
+	AssignUserRolesFunc      func(context.Context, int, []int) (*foreman.User, error)
+	AssignUserTaxonomiesFunc func(context.Context, int, []int, []int) (*foreman.User, error)
+	AddUserAssignmentsFunc   func(context.Context, int, []int, []int, []int) (*foreman.User, error)
+	ResolveUserFunc          func(context.Context, string) (int, error)
+
+	// UsergroupsService
+	ListUsergroupsFunc           func(context.Context, string) ([]foreman.Usergroup, error)
+	GetUsergroupFunc             func(context.Context, int) (*foreman.Usergroup, error)
+	CreateUsergroupFunc          func(context.Context, foreman.Usergroup) (*foreman.Usergroup, error)
+	UpdateUsergroupFunc          func(context.Context, int, foreman.Usergroup) (*foreman.Usergroup, error)
+	AddUsergroupMembersFunc      func(context.Context, int, []int, []int) (*foreman.Usergroup, error)
+	DeleteUsergroupFunc          func(context.Context, int) error
+	ResolveUsergroupFunc         func(context.Context, string) (int, error)
+	ListExternalUsergroupsFunc   func(context.Context, int) ([]foreman.ExternalUsergroup, error)
+	CreateExternalUsergroupFunc  func(context.Context, int, foreman.ExternalUsergroup) (*foreman.ExternalUsergroup, error)
+	DeleteExternalUsergroupFunc  func(context.Context, int, int) error
+	RefreshExternalUsergroupFunc func(context.Context, int, int) error
+
+	// RolesService
+	ListRolesFunc       func(context.Context, string) ([]foreman.Role, error)
+	GetRoleFunc         func(context.Context, int) (*foreman.Role, error)
+	CreateRoleFunc      func(context.Context, foreman.Role) (*foreman.Role, error)
+	UpdateRoleFunc      func(context.Context, int, foreman.Role) (*foreman.Role, error)
+	DeleteRoleFunc      func(context.Context, int) error
+	ResolveRoleFunc     func(context.Context, string) (int, error)
+	ListFiltersFunc     func(context.Context, string) ([]foreman.Filter, error)
+	ListRoleFiltersFunc func(context.Context, int) ([]foreman.Filter, error)
+	CreateFilterFunc    func(context.Context, foreman.Filter) (*foreman.Filter, error)
+	DeleteFilterFunc    func(context.Context, int) error
+	ListPermissionsFunc func(context.Context, string) ([]foreman.Permission, error)
+
+	// PersonalAccessTokensService
+	ListPersonalAccessTokensFunc  func(context.Context, int) ([]foreman.PersonalAccessToken, error)
+	GetPersonalAccessTokenFunc    func(context.Context, int, int) (*foreman.PersonalAccessToken, error)
+	CreatePersonalAccessTokenFunc func(context.Context, int, string, time.Time) (*foreman.PersonalAccessToken, error)
+	RevokePersonalAccessTokenFunc func(context.Context, int, int) error
+
+	// PuppetService
+	ListPuppetClassesFunc          func(context.Context, string) ([]foreman.PuppetClass, error)
+	ResolvePuppetClassFunc         func(context.Context, string) (int, error)
+	ListEnvironmentsFunc           func(context.Context, string) ([]foreman.Environment, error)
+	ResolveEnvironmentFunc         func(context.Context, string) (int, error)
+	AddHostPuppetClassFunc         func(context.Context, int) error
+	RemoveHostPuppetClassFunc      func(context.Context, int) error
+	AddHostgroupPuppetClassFunc    func(context.Context, int, int) error
+	RemoveHostgroupPuppetClassFunc func(context.Context, int, int) error
+	ImportPuppetClassesFunc        func(context.Context, int, int, bool) (map[string]interface{}, error)
+	ListSmartClassParametersFunc   func(context.Context, string) ([]foreman.SmartClassParameter, error)
+	GetSmartClassParameterFunc     func(context.Context, int) (*foreman.SmartClassParameter, error)
+	UpdateSmartClassParameterFunc  func(context.Context, int, foreman.SmartClassParameter) (*foreman.SmartClassParameter, error)
+	ResolveSmartClassParameterFunc func(context.Context, string, string) (int, error)
+	ListOverrideValuesFunc         func(context.Context, int) ([]foreman.OverrideValue, error)
+	SetOverrideValueFunc           func(context.Context, int, string, interface{}) (*foreman.OverrideValue, error)
+	DeleteOverrideValueFunc        func(context.Context, int, int) error
+	GetHostENCFunc                 func(context.Context) (*foreman.ENC, error)
+
+	// AnsibleService
+	ListAnsibleRolesFunc            func(context.Context, string) ([]foreman.AnsibleRole, error)
+	ResolveAnsibleRoleFunc          func(context.Context, string) (int, error)
+	ImportAnsibleRolesFunc          func(context.Context, int) (map[string]interface{}, error)
+	ListHostAnsibleRolesFunc        func(context.Context) ([]foreman.AnsibleRole, error)
+	AssignHostAnsibleRolesFunc      func(context.Context, []int) error
+	PlayHostAnsibleRolesFunc        func(context.Context) (*foreman.JobInvocation, error)
+	ListHostgroupAnsibleRolesFunc   func(context.Context, int) ([]foreman.AnsibleRole, error)
+	AssignHostgroupAnsibleRolesFunc func(context.Context, int, []int) error
+	PlayHostgroupAnsibleRolesFunc   func(context.Context, int) (*foreman.JobInvocation, error)
+	ListAnsibleVariablesFunc        func(context.Context, string) ([]foreman.AnsibleVariable, error)
+	GetAnsibleVariableFunc          func(context.Context, int) (*foreman.AnsibleVariable, error)
+	CreateAnsibleVariableFunc       func(context.Context, foreman.AnsibleVariable) (*foreman.AnsibleVariable, error)
+	UpdateAnsibleVariableFunc       func(context.Context, int, foreman.AnsibleVariable) (*foreman.AnsibleVariable, error)
+	DeleteAnsibleVariableFunc       func(context.Context, int) error
+	ResolveAnsibleVariableFunc      func(context.Context, string) (int, error)
+	SetAnsibleOverrideValueFunc     func(context.Context, int, string, interface{}) (*foreman.OverrideValue, error)
+	DeleteAnsibleOverrideValueFunc  func(context.Context, int) error
+
+	// SettingsService
+	ListSettingsFunc  func(context.Context, string) ([]foreman.Setting, error)
+	GetSettingFunc    func(context.Context, string) (*foreman.Setting, error)
+	UpdateSettingFunc func(context.Context, string, interface{}) (*foreman.Setting, error)
+	CheckSettingsFunc func(context.Context, map[string]interface{}) ([]foreman.SettingDrift, error)
+
+	// BookmarksService
+	ListBookmarksFunc   func(context.Context, string) ([]foreman.Bookmark, error)
+	GetBookmarkFunc     func(context.Context, int) (*foreman.Bookmark, error)
+	CreateBookmarkFunc  func(context.Context, foreman.Bookmark) (*foreman.Bookmark, error)
+	UpdateBookmarkFunc  func(context.Context, int, foreman.Bookmark) (*foreman.Bookmark, error)
+	DeleteBookmarkFunc  func(context.Context, int) error
+	ResolveBookmarkFunc func(context.Context, string, string) (string, error)
+
+	mu    sync.Mutex
+	calls []string
+}
+
+// Calls returns the names of the methods called, in order
+func (c *Client) Calls() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.calls...)
+}
+
+// record adds the method to the calls made
+func (c *Client) record(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, method)
+}
+
+// errNotMocked returns the error for a method called without its function set
+func errNotMocked(method string) error {
+	return fmt.Errorf("foremanmock: %s %w", method, ErrNotMocked)
+}
+
+// CheckStatus calls CheckStatusFunc
+func (c *Client) CheckStatus(ctx context.Context) (bool, string, error) {
+	c.record("CheckStatus")
+	if c.CheckStatusFunc == nil {
+		return false, "", errNotMocked("CheckStatus")
+	}
+	return c.CheckStatusFunc(ctx)
+}
+
+// CheckHost calls CheckHostFunc
+func (c *Client) CheckHost(ctx context.Context) (bool, string, error) {
+	c.record("CheckHost")
+	if c.CheckHostFunc == nil {
+		return false, "", errNotMocked("CheckHost")
+	}
+	return c.CheckHostFunc(ctx)
+}
+
+// CreateHost calls CreateHostFunc
+func (c *Client) CreateHost(ctx context.Context) (bool, string, error) {
+	c.record("CreateHost")
+	if c.CreateHostFunc == nil {
+		return false, "", errNotMocked("CreateHost")
+	}
+	return c.CreateHostFunc(ctx)
+}
+
+// DeleteHost calls DeleteHostFunc
+func (c *Client) DeleteHost(ctx context.Context) (bool, string, error) {
+	c.record("DeleteHost")
+	if c.DeleteHostFunc == nil {
+		return false, "", errNotMocked("DeleteHost")
+	}
+	return c.DeleteHostFunc(ctx)
+}
+
+// RebuildHost calls RebuildHostFunc
+func (c *Client) RebuildHost(ctx context.Context, powerCycle bool) error {
+	c.record("RebuildHost")
+	if c.RebuildHostFunc == nil {
+		return errNotMocked("RebuildHost")
+	}
+	return c.RebuildHostFunc(ctx, powerCycle)
+}
+
+// CancelBuild calls CancelBuildFunc
+func (c *Client) CancelBuild(ctx context.Context) error {
+	c.record("CancelBuild")
+	if c.CancelBuildFunc == nil {
+		return errNotMocked("CancelBuild")
+	}
+	return c.CancelBuildFunc(ctx)
+}
+
+// PowerHost calls PowerHostFunc
+func (c *Client) PowerHost(ctx context.Context, action string) error {
+	c.record("PowerHost")
+	if c.PowerHostFunc == nil {
+		return errNotMocked("PowerHost")
+	}
+	return c.PowerHostFunc(ctx, action)
+}
+
+// GetBuildStatus calls GetBuildStatusFunc
+func (c *Client) GetBuildStatus(ctx context.Context) (*foreman.BuildStatus, error) {
+	c.record("GetBuildStatus")
+	if c.GetBuildStatusFunc == nil {
+		return nil, errNotMocked("GetBuildStatus")
+	}
+	return c.GetBuildStatusFunc(ctx)
+}
+
+// RebuildConfig calls RebuildConfigFunc
+func (c *Client) RebuildConfig(ctx context.Context, only ...string) error {
+	c.record("RebuildConfig")
+	if c.RebuildConfigFunc == nil {
+		return errNotMocked("RebuildConfig")
+	}
+	return c.RebuildConfigFunc(ctx, only...)
+}
+
+// GetVMComputeAttributes calls GetVMComputeAttributesFunc
+func (c *Client) GetVMComputeAttributes(ctx context.Context) (map[string]interface{}, error) {
+	c.record("GetVMComputeAttributes")
+	if c.GetVMComputeAttributesFunc == nil {
+		return nil, errNotMocked("GetVMComputeAttributes")
+	}
+	return c.GetVMComputeAttributesFunc(ctx)
+}
+
+// ResolveHost calls ResolveHostFunc
+func (c *Client) ResolveHost(ctx context.Context, name string) (int, error) {
+	c.record("ResolveHost")
+	if c.ResolveHostFunc == nil {
+		return 0, errNotMocked("ResolveHost")
+	}
+	return c.ResolveHostFunc(ctx, name)
+}
+
+// GetHostBy calls GetHostByFunc
+func (c *Client) GetHostBy(ctx context.Context, selector string) (*foreman.Host, error) {
+	c.record("GetHostBy")
+	if c.GetHostByFunc == nil {
+		return nil, errNotMocked("GetHostBy")
+	}
+	return c.GetHostByFunc(ctx, selector)
+}
+
+// BulkDestroyHosts calls BulkDestroyHostsFunc
+func (c *Client) BulkDestroyHosts(ctx context.Context, search string) ([]foreman.BulkHostResult, error) {
+	c.record("BulkDestroyHosts")
+	if c.BulkDestroyHostsFunc == nil {
+		return nil, errNotMocked("BulkDestroyHosts")
+	}
+	return c.BulkDestroyHostsFunc(ctx, search)
+}
+
+// BulkReassignHostgroup calls BulkReassignHostgroupFunc
+func (c *Client) BulkReassignHostgroup(ctx context.Context, search string, hostgroupID int) ([]foreman.BulkHostResult, error) {
+	c.record("BulkReassignHostgroup")
+	if c.BulkReassignHostgroupFunc == nil {
+		return nil, errNotMocked("BulkReassignHostgroup")
+	}
+	return c.BulkReassignHostgroupFunc(ctx, search, hostgroupID)
+}
+
+// BulkChangeOwner calls BulkChangeOwnerFunc
+func (c *Client) BulkChangeOwner(ctx context.Context, search string, ownerID int, ownerType string) ([]foreman.BulkHostResult, error) {
+	c.record("BulkChangeOwner")
+	if c.BulkChangeOwnerFunc == nil {
+		return nil, errNotMocked("BulkChangeOwner")
+	}
+	return c.BulkChangeOwnerFunc(ctx, search, ownerID, ownerType)
+}
+
+// BulkChangeEnvironment calls BulkChangeEnvironmentFunc
+func (c *Client) BulkChangeEnvironment(ctx context.Context, search string, environmentID int) ([]foreman.BulkHostResult, error) {
+	c.record("BulkChangeEnvironment")
+	if c.BulkChangeEnvironmentFunc == nil {
+		return nil, errNotMocked("BulkChangeEnvironment")
+	}
+	return c.BulkChangeEnvironmentFunc(ctx, search, environmentID)
+}
+
+// BulkBuild calls BulkBuildFunc
+func (c *Client) BulkBuild(ctx context.Context, search string, reboot bool) ([]foreman.BulkHostResult, error) {
+	c.record("BulkBuild")
+	if c.BulkBuildFunc == nil {
+		return nil, errNotMocked("BulkBuild")
+	}
+	return c.BulkBuildFunc(ctx, search, reboot)
+}
+
+// BulkPower calls BulkPowerFunc
+func (c *Client) BulkPower(ctx context.Context, search string, action string) ([]foreman.BulkHostResult, error) {
+	c.record("BulkPower")
+	if c.BulkPowerFunc == nil {
+		return nil, errNotMocked("BulkPower")
+	}
+	return c.BulkPowerFunc(ctx, search, action)
+}
+
+// ResolveHostgroup calls ResolveHostgroupFunc
+func (c *Client) ResolveHostgroup(ctx context.Context, name string) (int, error) {
+	c.record("ResolveHostgroup")
+	if c.ResolveHostgroupFunc == nil {
+		return 0, errNotMocked("ResolveHostgroup")
+	}
+	return c.ResolveHostgroupFunc(ctx, name)
+}
+
+// ListOrganizations calls ListOrganizationsFunc
+func (c *Client) ListOrganizations(ctx context.Context, search string) ([]foreman.Organization, error) {
+	c.record("ListOrganizations")
+	if c.ListOrganizationsFunc == nil {
+		return nil, errNotMocked("ListOrganizations")
+	}
+	return c.ListOrganizationsFunc(ctx, search)
+}
+
+// ResolveOrganization calls ResolveOrganizationFunc
+func (c *Client) ResolveOrganization(ctx context.Context, name string) (int, error) {
+	c.record("ResolveOrganization")
+	if c.ResolveOrganizationFunc == nil {
+		return 0, errNotMocked("ResolveOrganization")
+	}
+	return c.ResolveOrganizationFunc(ctx, name)
+}
+
+// ListLocations calls ListLocationsFunc
+func (c *Client) ListLocations(ctx context.Context, search string) ([]foreman.Location, error) {
+	c.record("ListLocations")
+	if c.ListLocationsFunc == nil {
+		return nil, errNotMocked("ListLocations")
+	}
+	return c.ListLocationsFunc(ctx, search)
+}
+
+// ResolveLocation calls ResolveLocationFunc
+func (c *Client) ResolveLocation(ctx context.Context, name string) (int, error) {
+	c.record("ResolveLocation")
+	if c.ResolveLocationFunc == nil {
+		return 0, errNotMocked("ResolveLocation")
+	}
+	return c.ResolveLocationFunc(ctx, name)
+}
+
+// ListOperatingSystems calls ListOperatingSystemsFunc
+func (c *Client) ListOperatingSystems(ctx context.Context, search string) ([]foreman.OperatingSystem, error) {
+	c.record("ListOperatingSystems")
+	if c.ListOperatingSystemsFunc == nil {
+		return nil, errNotMocked("ListOperatingSystems")
+	}
+	return c.ListOperatingSystemsFunc(ctx, search)
+}
+
+// GetOperatingSystem calls GetOperatingSystemFunc
+func (c *Client) GetOperatingSystem(ctx context.Context, id int) (*foreman.OperatingSystem, error) {
+	c.record("GetOperatingSystem")
+	if c.GetOperatingSystemFunc == nil {
+		return nil, errNotMocked("GetOperatingSystem")
+	}
+	return c.GetOperatingSystemFunc(ctx, id)
+}
+
+// CreateOperatingSystem calls CreateOperatingSystemFunc
+func (c *Client) CreateOperatingSystem(ctx context.Context, os foreman.OperatingSystem) (*foreman.OperatingSystem, error) {
+	c.record("CreateOperatingSystem")
+	if c.CreateOperatingSystemFunc == nil {
+		return nil, errNotMocked("CreateOperatingSystem")
+	}
+	return c.CreateOperatingSystemFunc(ctx, os)
+}
+
+// UpdateOperatingSystem calls UpdateOperatingSystemFunc
+func (c *Client) UpdateOperatingSystem(ctx context.Context, id int, os foreman.OperatingSystem) (*foreman.OperatingSystem, error) {
+	c.record("UpdateOperatingSystem")
+	if c.UpdateOperatingSystemFunc == nil {
+		return nil, errNotMocked("UpdateOperatingSystem")
+	}
+	return c.UpdateOperatingSystemFunc(ctx, id, os)
+}
+
+// ResolveOperatingSystem calls ResolveOperatingSystemFunc
+func (c *Client) ResolveOperatingSystem(ctx context.Context, name string) (int, error) {
+	c.record("ResolveOperatingSystem")
+	if c.ResolveOperatingSystemFunc == nil {
+		return 0, errNotMocked("ResolveOperatingSystem")
+	}
+	return c.ResolveOperatingSystemFunc(ctx, name)
+}
+
+// ListArchitectures calls ListArchitecturesFunc
+func (c *Client) ListArchitectures(ctx context.Context, search string) ([]foreman.Architecture, error) {
+	c.record("ListArchitectures")
+	if c.ListArchitecturesFunc == nil {
+		return nil, errNotMocked("ListArchitectures")
+	}
+	return c.ListArchitecturesFunc(ctx, search)
+}
+
+// GetArchitecture calls GetArchitectureFunc
+func (c *Client) GetArchitecture(ctx context.Context, id int) (*foreman.Architecture, error) {
+	c.record("GetArchitecture")
+	if c.GetArchitectureFunc == nil {
+		return nil, errNotMocked("GetArchitecture")
+	}
+	return c.GetArchitectureFunc(ctx, id)
+}
+
+// CreateArchitecture calls CreateArchitectureFunc
+func (c *Client) CreateArchitecture(ctx context.Context, arch foreman.Architecture) (*foreman.Architecture, error) {
+	c.record("CreateArchitecture")
+	if c.CreateArchitectureFunc == nil {
+		return nil, errNotMocked("CreateArchitecture")
+	}
+	return c.CreateArchitectureFunc(ctx, arch)
+}
+
+// UpdateArchitecture calls UpdateArchitectureFunc
+func (c *Client) UpdateArchitecture(ctx context.Context, id int, arch foreman.Architecture) (*foreman.Architecture, error) {
+	c.record("UpdateArchitecture")
+	if c.UpdateArchitectureFunc == nil {
+		return nil, errNotMocked("UpdateArchitecture")
+	}
+	return c.UpdateArchitectureFunc(ctx, id, arch)
+}
+
+// ResolveArchitecture calls ResolveArchitectureFunc
+func (c *Client) ResolveArchitecture(ctx context.Context, name string) (int, error) {
+	c.record("ResolveArchitecture")
+	if c.ResolveArchitectureFunc == nil {
+		return 0, errNotMocked("ResolveArchitecture")
+	}
+	return c.ResolveArchitectureFunc(ctx, name)
+}
+
+// ListMedia calls ListMediaFunc
+func (c *Client) ListMedia(ctx context.Context, search string) ([]foreman.Medium, error) {
+	c.record("ListMedia")
+	if c.ListMediaFunc == nil {
+		return nil, errNotMocked("ListMedia")
+	}
+	return c.ListMediaFunc(ctx, search)
+}
+
+// GetMedium calls GetMediumFunc
+func (c *Client) GetMedium(ctx context.Context, id int) (*foreman.Medium, error) {
+	c.record("GetMedium")
+	if c.GetMediumFunc == nil {
+		return nil, errNotMocked("GetMedium")
+	}
+	return c.GetMediumFunc(ctx, id)
+}
+
+// CreateMedium calls CreateMediumFunc
+func (c *Client) CreateMedium(ctx context.Context, medium foreman.Medium) (*foreman.Medium, error) {
+	c.record("CreateMedium")
+	if c.CreateMediumFunc == nil {
+		return nil, errNotMocked("CreateMedium")
+	}
+	return c.CreateMediumFunc(ctx, medium)
+}
+
+// UpdateMedium calls UpdateMediumFunc
+func (c *Client) UpdateMedium(ctx context.Context, id int, medium foreman.Medium) (*foreman.Medium, error) {
+	c.record("UpdateMedium")
+	if c.UpdateMediumFunc == nil {
+		return nil, errNotMocked("UpdateMedium")
+	}
+	return c.UpdateMediumFunc(ctx, id, medium)
+}
+
+// ResolveMedium calls ResolveMediumFunc
+func (c *Client) ResolveMedium(ctx context.Context, name string) (int, error) {
+	c.record("ResolveMedium")
+	if c.ResolveMediumFunc == nil {
+		return 0, errNotMocked("ResolveMedium")
+	}
+	return c.ResolveMediumFunc(ctx, name)
+}
+
+// ListPartitionTables calls ListPartitionTablesFunc
+func (c *Client) ListPartitionTables(ctx context.Context, search string) ([]foreman.PartitionTable, error) {
+	c.record("ListPartitionTables")
+	if c.ListPartitionTablesFunc == nil {
+		return nil, errNotMocked("ListPartitionTables")
+	}
+	return c.ListPartitionTablesFunc(ctx, search)
+}
+
+// GetPartitionTable calls GetPartitionTableFunc
+func (c *Client) GetPartitionTable(ctx context.Context, id int) (*foreman.PartitionTable, error) {
+	c.record("GetPartitionTable")
+	if c.GetPartitionTableFunc == nil {
+		return nil, errNotMocked("GetPartitionTable")
+	}
+	return c.GetPartitionTableFunc(ctx, id)
+}
+
+// CreatePartitionTable calls CreatePartitionTableFunc
+func (c *Client) CreatePartitionTable(ctx context.Context, ptable foreman.PartitionTable) (*foreman.PartitionTable, error) {
+	c.record("CreatePartitionTable")
+	if c.CreatePartitionTableFunc == nil {
+		return nil, errNotMocked("CreatePartitionTable")
+	}
+	return c.CreatePartitionTableFunc(ctx, ptable)
+}
+
+// UpdatePartitionTable calls UpdatePartitionTableFunc
+func (c *Client) UpdatePartitionTable(ctx context.Context, id int, ptable foreman.PartitionTable) (*foreman.PartitionTable, error) {
+	c.record("UpdatePartitionTable")
+	if c.UpdatePartitionTableFunc == nil {
+		return nil, errNotMocked("UpdatePartitionTable")
+	}
+	return c.UpdatePartitionTableFunc(ctx, id, ptable)
+}
+
+// ResolvePartitionTable calls ResolvePartitionTableFunc
+func (c *Client) ResolvePartitionTable(ctx context.Context, name string) (int, error) {
+	c.record("ResolvePartitionTable")
+	if c.ResolvePartitionTableFunc == nil {
+		return 0, errNotMocked("ResolvePartitionTable")
+	}
+	return c.ResolvePartitionTableFunc(ctx, name)
+}
+
+// ListProvisioningTemplates calls ListProvisioningTemplatesFunc
+func (c *Client) ListProvisioningTemplates(ctx context.Context, search string) ([]foreman.ProvisioningTemplate, error) {
+	c.record("ListProvisioningTemplates")
+	if c.ListProvisioningTemplatesFunc == nil {
+		return nil, errNotMocked("ListProvisioningTemplates")
+	}
+	return c.ListProvisioningTemplatesFunc(ctx, search)
+}
+
+// GetProvisioningTemplate calls GetProvisioningTemplateFunc
+func (c *Client) GetProvisioningTemplate(ctx context.Context, id int) (*foreman.ProvisioningTemplate, error) {
+	c.record("GetProvisioningTemplate")
+	if c.GetProvisioningTemplateFunc == nil {
+		return nil, errNotMocked("GetProvisioningTemplate")
+	}
+	return c.GetProvisioningTemplateFunc(ctx, id)
+}
+
+// CreateProvisioningTemplate calls CreateProvisioningTemplateFunc
+func (c *Client) CreateProvisioningTemplate(ctx context.Context, template foreman.ProvisioningTemplate) (*foreman.ProvisioningTemplate, error) {
+	c.record("CreateProvisioningTemplate")
+	if c.CreateProvisioningTemplateFunc == nil {
+		return nil, errNotMocked("CreateProvisioningTemplate")
+	}
+	return c.CreateProvisioningTemplateFunc(ctx, template)
+}
+
+// UpdateProvisioningTemplate calls UpdateProvisioningTemplateFunc
+func (c *Client) UpdateProvisioningTemplate(ctx context.Context, id int, template foreman.ProvisioningTemplate) (*foreman.ProvisioningTemplate, error) {
+	c.record("UpdateProvisioningTemplate")
+	if c.UpdateProvisioningTemplateFunc == nil {
+		return nil, errNotMocked("UpdateProvisioningTemplate")
+	}
+	return c.UpdateProvisioningTemplateFunc(ctx, id, template)
+}
+
+// CloneProvisioningTemplate calls CloneProvisioningTemplateFunc
+func (c *Client) CloneProvisioningTemplate(ctx context.Context, id int, name string) (*foreman.ProvisioningTemplate, error) {
+	c.record("CloneProvisioningTemplate")
+	if c.CloneProvisioningTemplateFunc == nil {
+		return nil, errNotMocked("CloneProvisioningTemplate")
+	}
+	return c.CloneProvisioningTemplateFunc(ctx, id, name)
+}
+
+// LockProvisioningTemplate calls LockProvisioningTemplateFunc
+func (c *Client) LockProvisioningTemplate(ctx context.Context, id int) (*foreman.ProvisioningTemplate, error) {
+	c.record("LockProvisioningTemplate")
+	if c.LockProvisioningTemplateFunc == nil {
+		return nil, errNotMocked("LockProvisioningTemplate")
+	}
+	return c.LockProvisioningTemplateFunc(ctx, id)
+}
+
+// UnlockProvisioningTemplate calls UnlockProvisioningTemplateFunc
+func (c *Client) UnlockProvisioningTemplate(ctx context.Context, id int) (*foreman.ProvisioningTemplate, error) {
+	c.record("UnlockProvisioningTemplate")
+	if c.UnlockProvisioningTemplateFunc == nil {
+		return nil, errNotMocked("UnlockProvisioningTemplate")
+	}
+	return c.UnlockProvisioningTemplateFunc(ctx, id)
+}
+
+// AssociateProvisioningTemplate calls AssociateProvisioningTemplateFunc
+func (c *Client) AssociateProvisioningTemplate(ctx context.Context, id int, operatingSystemIDs []int) (*foreman.ProvisioningTemplate, error) {
+	c.record("AssociateProvisioningTemplate")
+	if c.AssociateProvisioningTemplateFunc == nil {
+		return nil, errNotMocked("AssociateProvisioningTemplate")
+	}
+	return c.AssociateProvisioningTemplateFunc(ctx, id, operatingSystemIDs)
+}
+
+// RenderProvisioningTemplate calls RenderProvisioningTemplateFunc
+func (c *Client) RenderProvisioningTemplate(ctx context.Context, id int, hostID int) (string, error) {
+	c.record("RenderProvisioningTemplate")
+	if c.RenderProvisioningTemplateFunc == nil {
+		return "", errNotMocked("RenderProvisioningTemplate")
+	}
+	return c.RenderProvisioningTemplateFunc(ctx, id, hostID)
+}
+
+// RenderHostTemplate calls RenderHostTemplateFunc
+func (c *Client) RenderHostTemplate(ctx context.Context, hostname string, kind string) (string, error) {
+	c.record("RenderHostTemplate")
+	if c.RenderHostTemplateFunc == nil {
+		return "", errNotMocked("RenderHostTemplate")
+	}
+	return c.RenderHostTemplateFunc(ctx, hostname, kind)
+}
+
+// ResolveProvisioningTemplate calls ResolveProvisioningTemplateFunc
+func (c *Client) ResolveProvisioningTemplate(ctx context.Context, name string) (int, error) {
+	c.record("ResolveProvisioningTemplate")
+	if c.ResolveProvisioningTemplateFunc == nil {
+		return 0, errNotMocked("ResolveProvisioningTemplate")
+	}
+	return c.ResolveProvisioningTemplateFunc(ctx, name)
+}
+
+// GetHostFacts calls GetHostFactsFunc
+func (c *Client) GetHostFacts(ctx context.Context) (map[string]string, error) {
+	c.record("GetHostFacts")
+	if c.GetHostFactsFunc == nil {
+		return nil, errNotMocked("GetHostFacts")
+	}
+	return c.GetHostFactsFunc(ctx)
+}
+
+// ListFactValues calls ListFactValuesFunc
+func (c *Client) ListFactValues(ctx context.Context, search string) (foreman.FactValues, error) {
+	c.record("ListFactValues")
+	if c.ListFactValuesFunc == nil {
+		return nil, errNotMocked("ListFactValues")
+	}
+	return c.ListFactValuesFunc(ctx, search)
+}
+
+// SearchHostsByFact calls SearchHostsByFactFunc
+func (c *Client) SearchHostsByFact(ctx context.Context, fact string, value string) ([]string, error) {
+	c.record("SearchHostsByFact")
+	if c.SearchHostsByFactFunc == nil {
+		return nil, errNotMocked("SearchHostsByFact")
+	}
+	return c.SearchHostsByFactFunc(ctx, fact, value)
+}
+
+// ListConfigReports calls ListConfigReportsFunc
+func (c *Client) ListConfigReports(ctx context.Context, search string) ([]foreman.ConfigReport, error) {
+	c.record("ListConfigReports")
+	if c.ListConfigReportsFunc == nil {
+		return nil, errNotMocked("ListConfigReports")
+	}
+	return c.ListConfigReportsFunc(ctx, search)
+}
+
+// GetConfigReport calls GetConfigReportFunc
+func (c *Client) GetConfigReport(ctx context.Context, id int) (*foreman.ConfigReport, error) {
+	c.record("GetConfigReport")
+	if c.GetConfigReportFunc == nil {
+		return nil, errNotMocked("GetConfigReport")
+	}
+	return c.GetConfigReportFunc(ctx, id)
+}
+
+// GetLastConfigReport calls GetLastConfigReportFunc
+func (c *Client) GetLastConfigReport(ctx context.Context) (*foreman.ConfigReport, error) {
+	c.record("GetLastConfigReport")
+	if c.GetLastConfigReportFunc == nil {
+		return nil, errNotMocked("GetLastConfigReport")
+	}
+	return c.GetLastConfigReportFunc(ctx)
+}
+
+// ListJobTemplates calls ListJobTemplatesFunc
+func (c *Client) ListJobTemplates(ctx context.Context, search string) ([]foreman.JobTemplate, error) {
+	c.record("ListJobTemplates")
+	if c.ListJobTemplatesFunc == nil {
+		return nil, errNotMocked("ListJobTemplates")
+	}
+	return c.ListJobTemplatesFunc(ctx, search)
+}
+
+// ResolveJobTemplate calls ResolveJobTemplateFunc
+func (c *Client) ResolveJobTemplate(ctx context.Context, name string) (int, error) {
+	c.record("ResolveJobTemplate")
+	if c.ResolveJobTemplateFunc == nil {
+		return 0, errNotMocked("ResolveJobTemplate")
+	}
+	return c.ResolveJobTemplateFunc(ctx, name)
+}
+
+// CreateJobInvocation calls CreateJobInvocationFunc
+func (c *Client) CreateJobInvocation(ctx context.Context, templateID int, search string, inputs map[string]string) (*foreman.JobInvocation, error) {
+	c.record("CreateJobInvocation")
+	if c.CreateJobInvocationFunc == nil {
+		return nil, errNotMocked("CreateJobInvocation")
+	}
+	return c.CreateJobInvocationFunc(ctx, templateID, search, inputs)
+}
+
+// GetJobInvocation calls GetJobInvocationFunc
+func (c *Client) GetJobInvocation(ctx context.Context, id int) (*foreman.JobInvocation, error) {
+	c.record("GetJobInvocation")
+	if c.GetJobInvocationFunc == nil {
+		return nil, errNotMocked("GetJobInvocation")
+	}
+	return c.GetJobInvocationFunc(ctx, id)
+}
+
+// WaitJobInvocation calls WaitJobInvocationFunc
+func (c *Client) WaitJobInvocation(ctx context.Context, id int, interval time.Duration) (*foreman.JobInvocation, error) {
+	c.record("WaitJobInvocation")
+	if c.WaitJobInvocationFunc == nil {
+		return nil, errNotMocked("WaitJobInvocation")
+	}
+	return c.WaitJobInvocationFunc(ctx, id, interval)
+}
+
+// ListJobInvocationHosts calls ListJobInvocationHostsFunc
+func (c *Client) ListJobInvocationHosts(ctx context.Context, id int) ([]foreman.JobInvocationHost, error) {
+	c.record("ListJobInvocationHosts")
+	if c.ListJobInvocationHostsFunc == nil {
+		return nil, errNotMocked("ListJobInvocationHosts")
+	}
+	return c.ListJobInvocationHostsFunc(ctx, id)
+}
+
+// GetJobOutput calls GetJobOutputFunc
+func (c *Client) GetJobOutput(ctx context.Context, id int, hostID int) (*foreman.JobOutput, error) {
+	c.record("GetJobOutput")
+	if c.GetJobOutputFunc == nil {
+		return nil, errNotMocked("GetJobOutput")
+	}
+	return c.GetJobOutputFunc(ctx, id, hostID)
+}
+
+// ListTasks calls ListTasksFunc
+func (c *Client) ListTasks(ctx context.Context, search string) ([]foreman.Task, error) {
+	c.record("ListTasks")
+	if c.ListTasksFunc == nil {
+		return nil, errNotMocked("ListTasks")
+	}
+	return c.ListTasksFunc(ctx, search)
+}
+
+// GetTask calls GetTaskFunc
+func (c *Client) GetTask(ctx context.Context, id string) (*foreman.Task, error) {
+	c.record("GetTask")
+	if c.GetTaskFunc == nil {
+		return nil, errNotMocked("GetTask")
+	}
+	return c.GetTaskFunc(ctx, id)
+}
+
+// CancelTask calls CancelTaskFunc
+func (c *Client) CancelTask(ctx context.Context, id string) error {
+	c.record("CancelTask")
+	if c.CancelTaskFunc == nil {
+		return errNotMocked("CancelTask")
+	}
+	return c.CancelTaskFunc(ctx, id)
+}
+
+// WaitTask calls WaitTaskFunc
+func (c *Client) WaitTask(ctx context.Context, id string, interval time.Duration, progress func(*foreman.Task)) (*foreman.Task, error) {
+	c.record("WaitTask")
+	if c.WaitTaskFunc == nil {
+		return nil, errNotMocked("WaitTask")
+	}
+	return c.WaitTaskFunc(ctx, id, interval, progress)
+}
+
+// ListAudits calls ListAuditsFunc
+func (c *Client) ListAudits(ctx context.Context, search string) ([]foreman.Audit, error) {
+	c.record("ListAudits")
+	if c.ListAuditsFunc == nil {
+		return nil, errNotMocked("ListAudits")
+	}
+	return c.ListAuditsFunc(ctx, search)
+}
+
+// FilterAudits calls FilterAuditsFunc
+func (c *Client) FilterAudits(ctx context.Context, filter foreman.AuditFilter) ([]foreman.Audit, error) {
+	c.record("FilterAudits")
+	if c.FilterAuditsFunc == nil {
+		return nil, errNotMocked("FilterAudits")
+	}
+	return c.FilterAuditsFunc(ctx, filter)
+}
+
+// ListUsers calls ListUsersFunc
+func (c *Client) ListUsers(ctx context.Context, search string) ([]foreman.User, error) {
+	c.record("ListUsers")
+	if c.ListUsersFunc == nil {
+		return nil, errNotMocked("ListUsers")
+	}
+	return c.ListUsersFunc(ctx, search)
+}
+
+// GetUser calls GetUserFunc
+func (c *Client) GetUser(ctx context.Context, id int) (*foreman.User, error) {
+	c.record("GetUser")
+	if c.GetUserFunc == nil {
+		return nil, errNotMocked("GetUser")
+	}
+	return c.GetUserFunc(ctx, id)
+}
+
+// CreateUser calls CreateUserFunc
+func (c *Client) CreateUser(ctx context.Context, user foreman.User) (*foreman.User, error) {
+	c.record("CreateUser")
+	if c.CreateUserFunc == nil {
+		return nil, errNotMocked("CreateUser")
+	}
+	return c.CreateUserFunc(ctx, user)
+}
+
+// UpdateUser calls UpdateUserFunc
+func (c *Client) UpdateUser(ctx context.Context, id int, user foreman.User) (*foreman.User, error) {
+	c.record("UpdateUser")
+	if c.UpdateUserFunc == nil {
+		return nil, errNotMocked("UpdateUser")
+	}
+	return c.UpdateUserFunc(ctx, id, user)
+}
+
+// DeleteUser calls DeleteUserFunc
+func (c *Client) DeleteUser(ctx context.Context, id int) error {
+	c.record("DeleteUser")
+	if c.DeleteUserFunc == nil {
+		return errNotMocked("DeleteUser")
+	}
+	return c.DeleteUserFunc(ctx, id)
+}
+
+// AssignUserRoles calls AssignUserRolesFunc
+func (c *Client) AssignUserRoles(ctx context.Context, id int, roleIDs []int) (*foreman.User, error) {
+	c.record("AssignUserRoles")
+	if c.AssignUserRolesFunc == nil {
+		return nil, errNotMocked("AssignUserRoles")
+	}
+	return c.AssignUserRolesFunc(ctx, id, roleIDs)
+}
+
+// AssignUserTaxonomies calls AssignUserTaxonomiesFunc
+func (c *Client) AssignUserTaxonomies(ctx context.Context, id int, organizationIDs []int, locationIDs []int) (*foreman.User, error) {
+	c.record("AssignUserTaxonomies")
+	if c.AssignUserTaxonomiesFunc == nil {
+		return nil, errNotMocked("AssignUserTaxonomies")
+	}
+	return c.AssignUserTaxonomiesFunc(ctx, id, organizationIDs, locationIDs)
+}
+
+// AddUserAssignments calls AddUserAssignmentsFunc
+func (c *Client) AddUserAssignments(ctx context.Context, id int, roleIDs []int, organizationIDs []int, locationIDs []int) (*foreman.User, error) {
+	c.record("AddUserAssignments")
+	if c.AddUserAssignmentsFunc == nil {
+		return nil, errNotMocked("AddUserAssignments")
+	}
+	return c.AddUserAssignmentsFunc(ctx, id, roleIDs, organizationIDs, locationIDs)
+}
+
+// ResolveUser calls ResolveUserFunc
+func (c *Client) ResolveUser(ctx context.Context, login string) (int, error) {
+	c.record("ResolveUser")
+	if c.ResolveUserFunc == nil {
+		return 0, errNotMocked("ResolveUser")
+	}
+	return c.ResolveUserFunc(ctx, login)
+}
+
+// ListUsergroups calls ListUsergroupsFunc
+func (c *Client) ListUsergroups(ctx context.Context, search string) ([]foreman.Usergroup, error) {
+	c.record("ListUsergroups")
+	if c.ListUsergroupsFunc == nil {
+		return nil, errNotMocked("ListUsergroups")
+	}
+	return c.ListUsergroupsFunc(ctx, search)
+}
+
+// GetUsergroup calls GetUsergroupFunc
+func (c *Client) GetUsergroup(ctx context.Context, id int) (*foreman.Usergroup, error) {
+	c.record("GetUsergroup")
+	if c.GetUsergroupFunc == nil {
+		return nil, errNotMocked("GetUsergroup")
+	}
+	return c.GetUsergroupFunc(ctx, id)
+}
+
+// CreateUsergroup calls CreateUsergroupFunc
+func (c *Client) CreateUsergroup(ctx context.Context, group foreman.Usergroup) (*foreman.Usergroup, error) {
+	c.record("CreateUsergroup")
+	if c.CreateUsergroupFunc == nil {
+		return nil, errNotMocked("CreateUsergroup")
+	}
+	return c.CreateUsergroupFunc(ctx, group)
+}
+
+// UpdateUsergroup calls UpdateUsergroupFunc
+func (c *Client) UpdateUsergroup(ctx context.Context, id int, group foreman.Usergroup) (*foreman.Usergroup, error) {
+	c.record("UpdateUsergroup")
+	if c.UpdateUsergroupFunc == nil {
+		return nil, errNotMocked("UpdateUsergroup")
+	}
+	return c.UpdateUsergroupFunc(ctx, id, group)
+}
+
+// AddUsergroupMembers calls AddUsergroupMembersFunc
+func (c *Client) AddUsergroupMembers(ctx context.Context, id int, userIDs []int, roleIDs []int) (*foreman.Usergroup, error) {
+	c.record("AddUsergroupMembers")
+	if c.AddUsergroupMembersFunc == nil {
+		return nil, errNotMocked("AddUsergroupMembers")
+	}
+	return c.AddUsergroupMembersFunc(ctx, id, userIDs, roleIDs)
+}
+
+// DeleteUsergroup calls DeleteUsergroupFunc
+func (c *Client) DeleteUsergroup(ctx context.Context, id int) error {
+	c.record("DeleteUsergroup")
+	if c.DeleteUsergroupFunc == nil {
+		return errNotMocked("DeleteUsergroup")
+	}
+	return c.DeleteUsergroupFunc(ctx, id)
+}
+
+// ResolveUsergroup calls ResolveUsergroupFunc
+func (c *Client) ResolveUsergroup(ctx context.Context, name string) (int, error) {
+	c.record("ResolveUsergroup")
+	if c.ResolveUsergroupFunc == nil {
+		return 0, errNotMocked("ResolveUsergroup")
+	}
+	return c.ResolveUsergroupFunc(ctx, name)
+}
+
+// ListExternalUsergroups calls ListExternalUsergroupsFunc
+func (c *Client) ListExternalUsergroups(ctx context.Context, usergroupID int) ([]foreman.ExternalUsergroup, error) {
+	c.record("ListExternalUsergroups")
+	if c.ListExternalUsergroupsFunc == nil {
+		return nil, errNotMocked("ListExternalUsergroups")
+	}
+	return c.ListExternalUsergroupsFunc(ctx, usergroupID)
+}
+
+// CreateExternalUsergroup calls CreateExternalUsergroupFunc
+func (c *Client) CreateExternalUsergroup(ctx context.Context, usergroupID int, group foreman.ExternalUsergroup) (*foreman.ExternalUsergroup, error) {
+	c.record("CreateExternalUsergroup")
+	if c.CreateExternalUsergroupFunc == nil {
+		return nil, errNotMocked("CreateExternalUsergroup")
+	}
+	return c.CreateExternalUsergroupFunc(ctx, usergroupID, group)
+}
+
+// DeleteExternalUsergroup calls DeleteExternalUsergroupFunc
+func (c *Client) DeleteExternalUsergroup(ctx context.Context, usergroupID int, id int) error {
+	c.record("DeleteExternalUsergroup")
+	if c.DeleteExternalUsergroupFunc == nil {
+		return errNotMocked("DeleteExternalUsergroup")
+	}
+	return c.DeleteExternalUsergroupFunc(ctx, usergroupID, id)
+}
+
+// RefreshExternalUsergroup calls RefreshExternalUsergroupFunc
+func (c *Client) RefreshExternalUsergroup(ctx context.Context, usergroupID int, id int) error {
+	c.record("RefreshExternalUsergroup")
+	if c.RefreshExternalUsergroupFunc == nil {
+		return errNotMocked("RefreshExternalUsergroup")
+	}
+	return c.RefreshExternalUsergroupFunc(ctx, usergroupID, id)
+}
+
+// ListRoles calls ListRolesFunc
+func (c *Client) ListRoles(ctx context.Context, search string) ([]foreman.Role, error) {
+	c.record("ListRoles")
+	if c.ListRolesFunc == nil {
+		return nil, errNotMocked("ListRoles")
+	}
+	return c.ListRolesFunc(ctx, search)
+}
+
+// GetRole calls GetRoleFunc
+func (c *Client) GetRole(ctx context.Context, id int) (*foreman.Role, error) {
+	c.record("GetRole")
+	if c.GetRoleFunc == nil {
+		return nil, errNotMocked("GetRole")
+	}
+	return c.GetRoleFunc(ctx, id)
+}
+
+// CreateRole calls CreateRoleFunc
+func (c *Client) CreateRole(ctx context.Context, role foreman.Role) (*foreman.Role, error) {
+	c.record("CreateRole")
+	if c.CreateRoleFunc == nil {
+		return nil, errNotMocked("CreateRole")
+	}
+	return c.CreateRoleFunc(ctx, role)
+}
+
+// UpdateRole calls UpdateRoleFunc
+func (c *Client) UpdateRole(ctx context.Context, id int, role foreman.Role) (*foreman.Role, error) {
+	c.record("UpdateRole")
+	if c.UpdateRoleFunc == nil {
+		return nil, errNotMocked("UpdateRole")
+	}
+	return c.UpdateRoleFunc(ctx, id, role)
+}
+
+// DeleteRole calls DeleteRoleFunc
+func (c *Client) DeleteRole(ctx context.Context, id int) error {
+	c.record("DeleteRole")
+	if c.DeleteRoleFunc == nil {
+		return errNotMocked("DeleteRole")
+	}
+	return c.DeleteRoleFunc(ctx, id)
+}
+
+// ResolveRole calls ResolveRoleFunc
+func (c *Client) ResolveRole(ctx context.Context, name string) (int, error) {
+	c.record("ResolveRole")
+	if c.ResolveRoleFunc == nil {
+		return 0, errNotMocked("ResolveRole")
+	}
+	return c.ResolveRoleFunc(ctx, name)
+}
+
+// ListFilters calls ListFiltersFunc
+func (c *Client) ListFilters(ctx context.Context, search string) ([]foreman.Filter, error) {
+	c.record("ListFilters")
+	if c.ListFiltersFunc == nil {
+		return nil, errNotMocked("ListFilters")
+	}
+	return c.ListFiltersFunc(ctx, search)
+}
+
+// ListRoleFilters calls ListRoleFiltersFunc
+func (c *Client) ListRoleFilters(ctx context.Context, roleID int) ([]foreman.Filter, error) {
+	c.record("ListRoleFilters")
+	if c.ListRoleFiltersFunc == nil {
+		return nil, errNotMocked("ListRoleFilters")
+	}
+	return c.ListRoleFiltersFunc(ctx, roleID)
+}
+
+// CreateFilter calls CreateFilterFunc
+func (c *Client) CreateFilter(ctx context.Context, filter foreman.Filter) (*foreman.Filter, error) {
+	c.record("CreateFilter")
+	if c.CreateFilterFunc == nil {
+		return nil, errNotMocked("CreateFilter")
+	}
+	return c.CreateFilterFunc(ctx, filter)
+}
+
+// DeleteFilter calls DeleteFilterFunc
+func (c *Client) DeleteFilter(ctx context.Context, id int) error {
+	c.record("DeleteFilter")
+	if c.DeleteFilterFunc == nil {
+		return errNotMocked("DeleteFilter")
+	}
+	return c.DeleteFilterFunc(ctx, id)
+}
+
+// ListPermissions calls ListPermissionsFunc
+func (c *Client) ListPermissions(ctx context.Context, search string) ([]foreman.Permission, error) {
+	c.record("ListPermissions")
+	if c.ListPermissionsFunc == nil {
+		return nil, errNotMocked("ListPermissions")
+	}
+	return c.ListPermissionsFunc(ctx, search)
+}
+
+// ListPersonalAccessTokens calls ListPersonalAccessTokensFunc
+func (c *Client) ListPersonalAccessTokens(ctx context.Context, userID int) ([]foreman.PersonalAccessToken, error) {
+	c.record("ListPersonalAccessTokens")
+	if c.ListPersonalAccessTokensFunc == nil {
+		return nil, errNotMocked("ListPersonalAccessTokens")
+	}
+	return c.ListPersonalAccessTokensFunc(ctx, userID)
+}
+
+// GetPersonalAccessToken calls GetPersonalAccessTokenFunc
+func (c *Client) GetPersonalAccessToken(ctx context.Context, userID int, id int) (*foreman.PersonalAccessToken, error) {
+	c.record("GetPersonalAccessToken")
+	if c.GetPersonalAccessTokenFunc == nil {
+		return nil, errNotMocked("GetPersonalAccessToken")
+	}
+	return c.GetPersonalAccessTokenFunc(ctx, userID, id)
+}
+
+// CreatePersonalAccessToken calls CreatePersonalAccessTokenFunc
+func (c *Client) CreatePersonalAccessToken(ctx context.Context, userID int, name string, expiresAt time.Time) (*foreman.PersonalAccessToken, error) {
+	c.record("CreatePersonalAccessToken")
+	if c.CreatePersonalAccessTokenFunc == nil {
+		return nil, errNotMocked("CreatePersonalAccessToken")
+	}
+	return c.CreatePersonalAccessTokenFunc(ctx, userID, name, expiresAt)
+}
+
+// RevokePersonalAccessToken calls RevokePersonalAccessTokenFunc
+func (c *Client) RevokePersonalAccessToken(ctx context.Context, userID int, id int) error {
+	c.record("RevokePersonalAccessToken")
+	if c.RevokePersonalAccessTokenFunc == nil {
+		return errNotMocked("RevokePersonalAccessToken")
+	}
+	return c.RevokePersonalAccessTokenFunc(ctx, userID, id)
+}
+
+// ListPuppetClasses calls ListPuppetClassesFunc
+func (c *Client) ListPuppetClasses(ctx context.Context, search string) ([]foreman.PuppetClass, error) {
+	c.record("ListPuppetClasses")
+	if c.ListPuppetClassesFunc == nil {
+		return nil, errNotMocked("ListPuppetClasses")
+	}
+	return c.ListPuppetClassesFunc(ctx, search)
+}
+
+// ResolvePuppetClass calls ResolvePuppetClassFunc
+func (c *Client) ResolvePuppetClass(ctx context.Context, name string) (int, error) {
+	c.record("ResolvePuppetClass")
+	if c.ResolvePuppetClassFunc == nil {
+		return 0, errNotMocked("ResolvePuppetClass")
+	}
+	return c.ResolvePuppetClassFunc(ctx, name)
+}
+
+// ListEnvironments calls ListEnvironmentsFunc
+func (c *Client) ListEnvironments(ctx context.Context, search string) ([]foreman.Environment, error) {
+	c.record("ListEnvironments")
+	if c.ListEnvironmentsFunc == nil {
+		return nil, errNotMocked("ListEnvironments")
+	}
+	return c.ListEnvironmentsFunc(ctx, search)
+}
+
+// ResolveEnvironment calls ResolveEnvironmentFunc
+func (c *Client) ResolveEnvironment(ctx context.Context, name string) (int, error) {
+	c.record("ResolveEnvironment")
+	if c.ResolveEnvironmentFunc == nil {
+		return 0, errNotMocked("ResolveEnvironment")
+	}
+	return c.ResolveEnvironmentFunc(ctx, name)
+}
+
+// AddHostPuppetClass calls AddHostPuppetClassFunc
+func (c *Client) AddHostPuppetClass(ctx context.Context, classID int) error {
+	c.record("AddHostPuppetClass")
+	if c.AddHostPuppetClassFunc == nil {
+		return errNotMocked("AddHostPuppetClass")
+	}
+	return c.AddHostPuppetClassFunc(ctx, classID)
+}
+
+// RemoveHostPuppetClass calls RemoveHostPuppetClassFunc
+func (c *Client) RemoveHostPuppetClass(ctx context.Context, classID int) error {
+	c.record("RemoveHostPuppetClass")
+	if c.RemoveHostPuppetClassFunc == nil {
+		return errNotMocked("RemoveHostPuppetClass")
+	}
+	return c.RemoveHostPuppetClassFunc(ctx, classID)
+}
+
+// AddHostgroupPuppetClass calls AddHostgroupPuppetClassFunc
+func (c *Client) AddHostgroupPuppetClass(ctx context.Context, hostgroupID int, classID int) error {
+	c.record("AddHostgroupPuppetClass")
+	if c.AddHostgroupPuppetClassFunc == nil {
+		return errNotMocked("AddHostgroupPuppetClass")
+	}
+	return c.AddHostgroupPuppetClassFunc(ctx, hostgroupID, classID)
+}
+
+// RemoveHostgroupPuppetClass calls RemoveHostgroupPuppetClassFunc
+func (c *Client) RemoveHostgroupPuppetClass(ctx context.Context, hostgroupID int, classID int) error {
+	c.record("RemoveHostgroupPuppetClass")
+	if c.RemoveHostgroupPuppetClassFunc == nil {
+		return errNotMocked("RemoveHostgroupPuppetClass")
+	}
+	return c.RemoveHostgroupPuppetClassFunc(ctx, hostgroupID, classID)
+}
+
+// ImportPuppetClasses calls ImportPuppetClassesFunc
+func (c *Client) ImportPuppetClasses(ctx context.Context, proxyID int, environmentID int, dryrun bool) (map[string]interface{}, error) {
+	c.record("ImportPuppetClasses")
+	if c.ImportPuppetClassesFunc == nil {
+		return nil, errNotMocked("ImportPuppetClasses")
+	}
+	return c.ImportPuppetClassesFunc(ctx, proxyID, environmentID, dryrun)
+}
+
+// ListSmartClassParameters calls ListSmartClassParametersFunc
+func (c *Client) ListSmartClassParameters(ctx context.Context, search string) ([]foreman.SmartClassParameter, error) {
+	c.record("ListSmartClassParameters")
+	if c.ListSmartClassParametersFunc == nil {
+		return nil, errNotMocked("ListSmartClassParameters")
+	}
+	return c.ListSmartClassParametersFunc(ctx, search)
+}
+
+// GetSmartClassParameter calls GetSmartClassParameterFunc
+func (c *Client) GetSmartClassParameter(ctx context.Context, id int) (*foreman.SmartClassParameter, error) {
+	c.record("GetSmartClassParameter")
+	if c.GetSmartClassParameterFunc == nil {
+		return nil, errNotMocked("GetSmartClassParameter")
+	}
+	return c.GetSmartClassParameterFunc(ctx, id)
+}
+
+// UpdateSmartClassParameter calls UpdateSmartClassParameterFunc
+func (c *Client) UpdateSmartClassParameter(ctx context.Context, id int, param foreman.SmartClassParameter) (*foreman.SmartClassParameter, error) {
+	c.record("UpdateSmartClassParameter")
+	if c.UpdateSmartClassParameterFunc == nil {
+		return nil, errNotMocked("UpdateSmartClassParameter")
+	}
+	return c.UpdateSmartClassParameterFunc(ctx, id, param)
+}
+
+// ResolveSmartClassParameter calls ResolveSmartClassParameterFunc
+func (c *Client) ResolveSmartClassParameter(ctx context.Context, class string, parameter string) (int, error) {
+	c.record("ResolveSmartClassParameter")
+	if c.ResolveSmartClassParameterFunc == nil {
+		return 0, errNotMocked("ResolveSmartClassParameter")
+	}
+	return c.ResolveSmartClassParameterFunc(ctx, class, parameter)
+}
+
+// ListOverrideValues calls ListOverrideValuesFunc
+func (c *Client) ListOverrideValues(ctx context.Context, paramID int) ([]foreman.OverrideValue, error) {
+	c.record("ListOverrideValues")
+	if c.ListOverrideValuesFunc == nil {
+		return nil, errNotMocked("ListOverrideValues")
+	}
+	return c.ListOverrideValuesFunc(ctx, paramID)
+}
+
+// SetOverrideValue calls SetOverrideValueFunc
+func (c *Client) SetOverrideValue(ctx context.Context, paramID int, match string, value interface{}) (*foreman.OverrideValue, error) {
+	c.record("SetOverrideValue")
+	if c.SetOverrideValueFunc == nil {
+		return nil, errNotMocked("SetOverrideValue")
+	}
+	return c.SetOverrideValueFunc(ctx, paramID, match, value)
+}
+
+// DeleteOverrideValue calls DeleteOverrideValueFunc
+func (c *Client) DeleteOverrideValue(ctx context.Context, paramID int, id int) error {
+	c.record("DeleteOverrideValue")
+	if c.DeleteOverrideValueFunc == nil {
+		return errNotMocked("DeleteOverrideValue")
+	}
+	return c.DeleteOverrideValueFunc(ctx, paramID, id)
+}
+
+// GetHostENC calls GetHostENCFunc
+func (c *Client) GetHostENC(ctx context.Context) (*foreman.ENC, error) {
+	c.record("GetHostENC")
+	if c.GetHostENCFunc == nil {
+		return nil, errNotMocked("GetHostENC")
+	}
+	return c.GetHostENCFunc(ctx)
+}
+
+// ListAnsibleRoles calls ListAnsibleRolesFunc
+func (c *Client) ListAnsibleRoles(ctx context.Context, search string) ([]foreman.AnsibleRole, error) {
+	c.record("ListAnsibleRoles")
+	if c.ListAnsibleRolesFunc == nil {
+		return nil, errNotMocked("ListAnsibleRoles")
+	}
+	return c.ListAnsibleRolesFunc(ctx, search)
+}
+
+// ResolveAnsibleRole calls ResolveAnsibleRoleFunc
+func (c *Client) ResolveAnsibleRole(ctx context.Context, name string) (int, error) {
+	c.record("ResolveAnsibleRole")
+	if c.ResolveAnsibleRoleFunc == nil {
+		return 0, errNotMocked("ResolveAnsibleRole")
+	}
+	return c.ResolveAnsibleRoleFunc(ctx, name)
+}
+
+// ImportAnsibleRoles calls ImportAnsibleRolesFunc
+func (c *Client) ImportAnsibleRoles(ctx context.Context, proxyID int) (map[string]interface{}, error) {
+	c.record("ImportAnsibleRoles")
+	if c.ImportAnsibleRolesFunc == nil {
+		return nil, errNotMocked("ImportAnsibleRoles")
+	}
+	return c.ImportAnsibleRolesFunc(ctx, proxyID)
+}
+
+// ListHostAnsibleRoles calls ListHostAnsibleRolesFunc
+func (c *Client) ListHostAnsibleRoles(ctx context.Context) ([]foreman.AnsibleRole, error) {
+	c.record("ListHostAnsibleRoles")
+	if c.ListHostAnsibleRolesFunc == nil {
+		return nil, errNotMocked("ListHostAnsibleRoles")
+	}
+	return c.ListHostAnsibleRolesFunc(ctx)
+}
+
+// AssignHostAnsibleRoles calls AssignHostAnsibleRolesFunc
+func (c *Client) AssignHostAnsibleRoles(ctx context.Context, roleIDs []int) error {
+	c.record("AssignHostAnsibleRoles")
+	if c.AssignHostAnsibleRolesFunc == nil {
+		return errNotMocked("AssignHostAnsibleRoles")
+	}
+	return c.AssignHostAnsibleRolesFunc(ctx, roleIDs)
+}
+
+// PlayHostAnsibleRoles calls PlayHostAnsibleRolesFunc
+func (c *Client) PlayHostAnsibleRoles(ctx context.Context) (*foreman.JobInvocation, error) {
+	c.record("PlayHostAnsibleRoles")
+	if c.PlayHostAnsibleRolesFunc == nil {
+		return nil, errNotMocked("PlayHostAnsibleRoles")
+	}
+	return c.PlayHostAnsibleRolesFunc(ctx)
+}
+
+// ListHostgroupAnsibleRoles calls ListHostgroupAnsibleRolesFunc
+func (c *Client) ListHostgroupAnsibleRoles(ctx context.Context, hostgroupID int) ([]foreman.AnsibleRole, error) {
+	c.record("ListHostgroupAnsibleRoles")
+	if c.ListHostgroupAnsibleRolesFunc == nil {
+		return nil, errNotMocked("ListHostgroupAnsibleRoles")
+	}
+	return c.ListHostgroupAnsibleRolesFunc(ctx, hostgroupID)
+}
+
+// AssignHostgroupAnsibleRoles calls AssignHostgroupAnsibleRolesFunc
+func (c *Client) AssignHostgroupAnsibleRoles(ctx context.Context, hostgroupID int, roleIDs []int) error {
+	c.record("AssignHostgroupAnsibleRoles")
+	if c.AssignHostgroupAnsibleRolesFunc == nil {
+		return errNotMocked("AssignHostgroupAnsibleRoles")
+	}
+	return c.AssignHostgroupAnsibleRolesFunc(ctx, hostgroupID, roleIDs)
+}
+
+// PlayHostgroupAnsibleRoles calls PlayHostgroupAnsibleRolesFunc
+func (c *Client) PlayHostgroupAnsibleRoles(ctx context.Context, hostgroupID int) (*foreman.JobInvocation, error) {
+	c.record("PlayHostgroupAnsibleRoles")
+	if c.PlayHostgroupAnsibleRolesFunc == nil {
+		return nil, errNotMocked("PlayHostgroupAnsibleRoles")
+	}
+	return c.PlayHostgroupAnsibleRolesFunc(ctx, hostgroupID)
+}
+
+// ListAnsibleVariables calls ListAnsibleVariablesFunc
+func (c *Client) ListAnsibleVariables(ctx context.Context, search string) ([]foreman.AnsibleVariable, error) {
+	c.record("ListAnsibleVariables")
+	if c.ListAnsibleVariablesFunc == nil {
+		return nil, errNotMocked("ListAnsibleVariables")
+	}
+	return c.ListAnsibleVariablesFunc(ctx, search)
+}
+
+// GetAnsibleVariable calls GetAnsibleVariableFunc
+func (c *Client) GetAnsibleVariable(ctx context.Context, id int) (*foreman.AnsibleVariable, error) {
+	c.record("GetAnsibleVariable")
+	if c.GetAnsibleVariableFunc == nil {
+		return nil, errNotMocked("GetAnsibleVariable")
+	}
+	return c.GetAnsibleVariableFunc(ctx, id)
+}
+
+// CreateAnsibleVariable calls CreateAnsibleVariableFunc
+func (c *Client) CreateAnsibleVariable(ctx context.Context, variable foreman.AnsibleVariable) (*foreman.AnsibleVariable, error) {
+	c.record("CreateAnsibleVariable")
+	if c.CreateAnsibleVariableFunc == nil {
+		return nil, errNotMocked("CreateAnsibleVariable")
+	}
+	return c.CreateAnsibleVariableFunc(ctx, variable)
+}
+
+// UpdateAnsibleVariable calls UpdateAnsibleVariableFunc
+func (c *Client) UpdateAnsibleVariable(ctx context.Context, id int, variable foreman.AnsibleVariable) (*foreman.AnsibleVariable, error) {
+	c.record("UpdateAnsibleVariable")
+	if c.UpdateAnsibleVariableFunc == nil {
+		return nil, errNotMocked("UpdateAnsibleVariable")
+	}
+	return c.UpdateAnsibleVariableFunc(ctx, id, variable)
+}
+
+// DeleteAnsibleVariable calls DeleteAnsibleVariableFunc
+func (c *Client) DeleteAnsibleVariable(ctx context.Context, id int) error {
+	c.record("DeleteAnsibleVariable")
+	if c.DeleteAnsibleVariableFunc == nil {
+		return errNotMocked("DeleteAnsibleVariable")
+	}
+	return c.DeleteAnsibleVariableFunc(ctx, id)
+}
+
+// ResolveAnsibleVariable calls ResolveAnsibleVariableFunc
+func (c *Client) ResolveAnsibleVariable(ctx context.Context, name string) (int, error) {
+	c.record("ResolveAnsibleVariable")
+	if c.ResolveAnsibleVariableFunc == nil {
+		return 0, errNotMocked("ResolveAnsibleVariable")
+	}
+	return c.ResolveAnsibleVariableFunc(ctx, name)
+}
+
+// SetAnsibleOverrideValue calls SetAnsibleOverrideValueFunc
+func (c *Client) SetAnsibleOverrideValue(ctx context.Context, variableID int, match string, value interface{}) (*foreman.OverrideValue, error) {
+	c.record("SetAnsibleOverrideValue")
+	if c.SetAnsibleOverrideValueFunc == nil {
+		return nil, errNotMocked("SetAnsibleOverrideValue")
+	}
+	return c.SetAnsibleOverrideValueFunc(ctx, variableID, match, value)
+}
+
+// DeleteAnsibleOverrideValue calls DeleteAnsibleOverrideValueFunc
+func (c *Client) DeleteAnsibleOverrideValue(ctx context.Context, id int) error {
+	c.record("DeleteAnsibleOverrideValue")
+	if c.DeleteAnsibleOverrideValueFunc == nil {
+		return errNotMocked("DeleteAnsibleOverrideValue")
+	}
+	return c.DeleteAnsibleOverrideValueFunc(ctx, id)
+}
+
+// ListSettings calls ListSettingsFunc
+func (c *Client) ListSettings(ctx context.Context, search string) ([]foreman.Setting, error) {
+	c.record("ListSettings")
+	if c.ListSettingsFunc == nil {
+		return nil, errNotMocked("ListSettings")
+	}
+	return c.ListSettingsFunc(ctx, search)
+}
+
+// GetSetting calls GetSettingFunc
+func (c *Client) GetSetting(ctx context.Context, name string) (*foreman.Setting, error) {
+	c.record("GetSetting")
+	if c.GetSettingFunc == nil {
+		return nil, errNotMocked("GetSetting")
+	}
+	return c.GetSettingFunc(ctx, name)
+}
+
+// UpdateSetting calls UpdateSettingFunc
+func (c *Client) UpdateSetting(ctx context.Context, name string, value interface{}) (*foreman.Setting, error) {
+	c.record("UpdateSetting")
+	if c.UpdateSettingFunc == nil {
+		return nil, errNotMocked("UpdateSetting")
+	}
+	return c.UpdateSettingFunc(ctx, name, value)
+}
+
+// CheckSettings calls CheckSettingsFunc
+func (c *Client) CheckSettings(ctx context.Context, expected map[string]interface{}) ([]foreman.SettingDrift, error) {
+	c.record("CheckSettings")
+	if c.CheckSettingsFunc == nil {
+		return nil, errNotMocked("CheckSettings")
+	}
+	return c.CheckSettingsFunc(ctx, expected)
+}
+
+// ListBookmarks calls ListBookmarksFunc
+func (c *Client) ListBookmarks(ctx context.Context, search string) ([]foreman.Bookmark, error) {
+	c.record("ListBookmarks")
+	if c.ListBookmarksFunc == nil {
+		return nil, errNotMocked("ListBookmarks")
+	}
+	return c.ListBookmarksFunc(ctx, search)
+}
+
+// GetBookmark calls GetBookmarkFunc
+func (c *Client) GetBookmark(ctx context.Context, id int) (*foreman.Bookmark, error) {
+	c.record("GetBookmark")
+	if c.GetBookmarkFunc == nil {
+		return nil, errNotMocked("GetBookmark")
+	}
+	return c.GetBookmarkFunc(ctx, id)
+}
+
+// CreateBookmark calls CreateBookmarkFunc
+func (c *Client) CreateBookmark(ctx context.Context, bookmark foreman.Bookmark) (*foreman.Bookmark, error) {
+	c.record("CreateBookmark")
+	if c.CreateBookmarkFunc == nil {
+		return nil, errNotMocked("CreateBookmark")
+	}
+	return c.CreateBookmarkFunc(ctx, bookmark)
+}
+
+// UpdateBookmark calls UpdateBookmarkFunc
+func (c *Client) UpdateBookmark(ctx context.Context, id int, bookmark foreman.Bookmark) (*foreman.Bookmark, error) {
+	c.record("UpdateBookmark")
+	if c.UpdateBookmarkFunc == nil {
+		return nil, errNotMocked("UpdateBookmark")
+	}
+	return c.UpdateBookmarkFunc(ctx, id, bookmark)
+}
+
+// DeleteBookmark calls DeleteBookmarkFunc
+func (c *Client) DeleteBookmark(ctx context.Context, id int) error {
+	c.record("DeleteBookmark")
+	if c.DeleteBookmarkFunc == nil {
+		return errNotMocked("DeleteBookmark")
+	}
+	return c.DeleteBookmarkFunc(ctx, id)
+}
+
+// ResolveBookmark calls ResolveBookmarkFunc
+func (c *Client) ResolveBookmark(ctx context.Context, name string, controller string) (string, error) {
+	c.record("ResolveBookmark")
+	if c.ResolveBookmarkFunc == nil {
+		return "", errNotMocked("ResolveBookmark")
+	}
+	return c.ResolveBookmarkFunc(ctx, name, controller)
+}
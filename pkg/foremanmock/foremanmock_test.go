@@ -0,0 +1,99 @@
+package foremanmock_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+	"github.com/bishy999/go-foreman/pkg/foremanmock"
+)
+
+// ensureHost creates the host when it does not exist, as a consumer of the HostsService would
+func ensureHost(ctx context.Context, hosts foreman.HostsService) (string, error) {
+
+	exists, _, err := hosts.CheckHost(ctx)
+	if err != nil || exists {
+		return "exists", err
+	}
+
+	_, status, err := hosts.CreateHost(ctx)
+
+	return status, err
+}
+
+func ExampleClient() {
+
+	mock := &foremanmock.Client{
+		CheckHostFunc: func(ctx context.Context) (bool, string, error) {
+			return false, "Resource host not found by id", nil
+		},
+		CreateHostFunc: func(ctx context.Context) (bool, string, error) {
+			return true, "The host [dev01] was created successfully", nil
+		},
+	}
+
+	status, err := ensureHost(context.Background(), mock)
+
+	fmt.Println(status, err)
+	fmt.Println(mock.Calls())
+
+	// Output: The host [dev01] was created successfully <nil>
+	// [CheckHost CreateHost]
+}
+
+func TestClient(t *testing.T) {
+
+	tt := []struct {
+		name   string
+		mock   *foremanmock.Client
+		status string
+		calls  []string
+		err    error
+	}{
+		{
+			name: "Host exists",
+			mock: &foremanmock.Client{
+				CheckHostFunc: func(ctx context.Context) (bool, string, error) { return true, "", nil },
+			},
+			status: "exists",
+			calls:  []string{"CheckHost"},
+		},
+		{
+			name: "Check fails",
+			mock: &foremanmock.Client{
+				CheckHostFunc: func(ctx context.Context) (bool, string, error) { return false, "", errors.New("unreachable") },
+			},
+			status: "exists",
+			calls:  []string{"CheckHost"},
+			err:    errors.New("unreachable"),
+		},
+		{
+			name: "Create not mocked",
+			mock: &foremanmock.Client{
+				CheckHostFunc: func(ctx context.Context) (bool, string, error) { return false, "", nil },
+			},
+			calls: []string{"CheckHost", "CreateHost"},
+			err:   foremanmock.ErrNotMocked,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+
+			status, err := ensureHost(context.Background(), tc.mock)
+
+			if status != tc.status {
+				t.Errorf("expected status %q got %q", tc.status, status)
+			}
+			if tc.err == nil && err != nil || tc.err != nil && (err == nil || !errors.Is(err, tc.err) && err.Error() != tc.err.Error()) {
+				t.Errorf("expected error %v got %v", tc.err, err)
+			}
+			if !reflect.DeepEqual(tc.mock.Calls(), tc.calls) {
+				t.Errorf("expected calls %v got %v", tc.calls, tc.mock.Calls())
+			}
+		})
+	}
+}
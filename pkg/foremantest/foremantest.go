@@ -0,0 +1,533 @@
+/*
+Package foremantest provides an in-memory Foreman server for testing code that uses the foreman package.
+
+The server keeps hosts, hostgroups, compute profiles, organizations and locations in memory and answers
+with the same json shapes, status codes and error bodies as the Foreman API, so tests can exercise
+realistic behavior without a live Foreman.
+*/
+package foremantest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+)
+
+const (
+	// Username accepted by the server unless changed
+	Username = "admin"
+	// Password accepted by the server unless changed
+	Password = "changeme"
+	// Version reported by the status endpoint
+	Version = "1.24.2"
+
+	timeLayout = "2006-01-02 15:04:05 UTC"
+)
+
+// Server is a stateful fake Foreman, it is safe for concurrent use
+type Server struct {
+	*httptest.Server
+
+	Username string
+	Password string
+
+	mu        sync.Mutex
+	nextID    int
+	resources map[string]*resource
+}
+
+// resource holds the records of a single api collection such as hosts
+type resource struct {
+	singular string
+	new      func() record
+	items    map[int]record
+}
+
+// record is implemented by every type stored by the server
+type record interface {
+	getID() int
+	setID(int)
+	getName() string
+	touch(now string)
+}
+
+// NewServer starts a fake Foreman server, it should be closed when the test finishes
+func NewServer() *Server {
+
+	s := &Server{
+		Username: Username,
+		Password: Password,
+		resources: map[string]*resource{
+			"hosts":            {singular: "host", new: func() record { return &Host{Enabled: true, Managed: true} }},
+			"hostgroups":       {singular: "hostgroup", new: func() record { return &Hostgroup{} }},
+			"compute_profiles": {singular: "compute_profile", new: func() record { return &ComputeProfile{} }},
+			"organizations":    {singular: "organization", new: func() record { return &Organization{} }},
+			"locations":        {singular: "location", new: func() record { return &Location{} }},
+		},
+	}
+	for _, r := range s.resources {
+		r.items = map[int]record{}
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// ConnectionInfo returns a foreman connection to the server with valid credentials
+func (s *Server) ConnectionInfo() foreman.ConnectionInfo {
+	return foreman.ConnectionInfo{
+		Username: s.Username,
+		Password: s.Password,
+		BaseURL:  s.URL,
+		Client:   s.Client(),
+	}
+}
+
+// AddHost stores a host, a zero ID is assigned the next free one
+func (s *Server) AddHost(h Host) Host {
+	s.add("hosts", &h)
+	return h
+}
+
+// AddHostgroup stores a hostgroup, a zero ID is assigned the next free one
+func (s *Server) AddHostgroup(hg Hostgroup) Hostgroup {
+	s.add("hostgroups", &hg)
+	return hg
+}
+
+// AddComputeProfile stores a compute profile, a zero ID is assigned the next free one
+func (s *Server) AddComputeProfile(cp ComputeProfile) ComputeProfile {
+	s.add("compute_profiles", &cp)
+	return cp
+}
+
+// AddOrganization stores an organization, a zero ID is assigned the next free one
+func (s *Server) AddOrganization(o Organization) Organization {
+	s.add("organizations", &o)
+	return o
+}
+
+// AddLocation stores a location, a zero ID is assigned the next free one
+func (s *Server) AddLocation(l Location) Location {
+	s.add("locations", &l)
+	return l
+}
+
+// Hosts returns a copy of the stored hosts ordered by id
+func (s *Server) Hosts() []Host {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var hosts []Host
+	for _, r := range s.sorted("hosts") {
+		hosts = append(hosts, *r.(*Host))
+	}
+
+	return hosts
+}
+
+// Host returns a copy of the host with the id or name provided
+func (s *Server) Host(idOrName string) (Host, bool) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.find("hosts", idOrName)
+	if r == nil {
+		return Host{}, false
+	}
+
+	return *r.(*Host), true
+}
+
+// add stores the record, assigning it an id when it has none
+func (s *Server) add(collection string, r record) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.getID() == 0 {
+		s.nextID++
+		r.setID(s.nextID)
+	} else if r.getID() > s.nextID {
+		s.nextID = r.getID()
+	}
+	r.touch(time.Now().UTC().Format(timeLayout))
+	s.resources[collection].items[r.getID()] = r
+}
+
+// sorted returns the records of the collection ordered by id, the lock must be held
+func (s *Server) sorted(collection string) []record {
+
+	items := s.resources[collection].items
+	ids := make([]int, 0, len(items))
+	for id := range items {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	records := make([]record, 0, len(ids))
+	for _, id := range ids {
+		records = append(records, items[id])
+	}
+
+	return records
+}
+
+// find returns the record with the id or name provided, the lock must be held
+func (s *Server) find(collection string, idOrName string) record {
+
+	items := s.resources[collection].items
+	if id, err := strconv.Atoi(idOrName); err == nil {
+		return items[id]
+	}
+	for _, r := range items {
+		if r.getName() == idOrName {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// exists reports whether a record with the id is stored, the lock must be held
+func (s *Server) exists(collection string, id int) bool {
+	_, ok := s.resources[collection].items[id]
+	return ok
+}
+
+// serveHTTP routes api requests to the stored collections
+func (s *Server) serveHTTP(rw http.ResponseWriter, req *http.Request) {
+
+	user, password, ok := req.BasicAuth()
+	if !ok || user != s.Username || password != s.Password {
+		writeError(rw, http.StatusUnauthorized, fmt.Sprintf("Unable to authenticate user %s", user))
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, "/api"), "/"), "/")
+
+	if parts[0] == "status" && req.Method == http.MethodGet {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"result": "ok", "status": http.StatusOK, "version": Version, "api_version": 2})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res, ok := s.resources[parts[0]]
+	if !ok || len(parts) > 2 {
+		writeError(rw, http.StatusNotFound, fmt.Sprintf("Route %s %s not found", req.Method, req.URL.Path))
+		return
+	}
+
+	if len(parts) == 1 {
+		switch req.Method {
+		case http.MethodGet:
+			s.index(rw, req, parts[0])
+		case http.MethodPost:
+			s.create(rw, req, parts[0], res)
+		default:
+			writeError(rw, http.StatusNotFound, fmt.Sprintf("Route %s %s not found", req.Method, req.URL.Path))
+		}
+		return
+	}
+
+	r := s.find(parts[0], parts[1])
+	if r == nil {
+		writeError(rw, http.StatusNotFound, fmt.Sprintf("Resource %s not found by id '%s'", res.singular, parts[1]))
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(rw, http.StatusOK, r)
+	case http.MethodPut, http.MethodPatch:
+		s.update(rw, req, parts[0], res, r)
+	case http.MethodDelete:
+		delete(res.items, r.getID())
+		writeJSON(rw, http.StatusOK, r)
+	default:
+		writeError(rw, http.StatusNotFound, fmt.Sprintf("Route %s %s not found", req.Method, req.URL.Path))
+	}
+}
+
+// index writes the paginated records of the collection that match the search
+func (s *Server) index(rw http.ResponseWriter, req *http.Request, collection string) {
+
+	query := req.URL.Query()
+	search := query.Get("search")
+
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	perPage, err := strconv.Atoi(query.Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = 20
+	}
+
+	all := s.sorted(collection)
+	var matched []record
+	for _, r := range all {
+		if matches(r, search) {
+			matched = append(matched, r)
+		}
+	}
+
+	results := []record{}
+	start := (page - 1) * perPage
+	for i := start; i < len(matched) && i < start+perPage; i++ {
+		results = append(results, matched[i])
+	}
+
+	writeJSON(rw, http.StatusOK, map[string]interface{}{
+		"total":    len(all),
+		"subtotal": len(matched),
+		"page":     page,
+		"per_page": perPage,
+		"search":   nilIfEmpty(search),
+		"sort":     map[string]interface{}{"by": nil, "order": nil},
+		"results":  results,
+	})
+}
+
+// create decodes a new record from the request and stores it if it is valid
+func (s *Server) create(rw http.ResponseWriter, req *http.Request, collection string, res *resource) {
+
+	r := res.new()
+	if !decode(rw, req, res.singular, r) {
+		return
+	}
+	r.setID(0)
+
+	if errs := s.validate(collection, r); len(errs) > 0 {
+		writeValidationError(rw, errs)
+		return
+	}
+
+	s.nextID++
+	r.setID(s.nextID)
+	r.touch(time.Now().UTC().Format(timeLayout))
+	s.link(r)
+	res.items[r.getID()] = r
+
+	writeJSON(rw, http.StatusCreated, r)
+}
+
+// update decodes the request onto a copy of the record and replaces it if the result is valid
+func (s *Server) update(rw http.ResponseWriter, req *http.Request, collection string, res *resource, r record) {
+
+	updated := res.new()
+	data, _ := json.Marshal(r)
+	_ = json.Unmarshal(data, updated)
+
+	if !decode(rw, req, res.singular, updated) {
+		return
+	}
+	updated.setID(r.getID())
+
+	if errs := s.validate(collection, updated); len(errs) > 0 {
+		writeValidationError(rw, errs)
+		return
+	}
+
+	updated.touch(time.Now().UTC().Format(timeLayout))
+	s.link(updated)
+	res.items[updated.getID()] = updated
+
+	writeJSON(rw, http.StatusOK, updated)
+}
+
+// validate returns the validation errors of the record keyed by field, the lock must be held
+func (s *Server) validate(collection string, r record) map[string][]string {
+
+	errs := map[string][]string{}
+
+	if r.getName() == "" {
+		errs["name"] = append(errs["name"], "can't be blank")
+	}
+	for _, other := range s.resources[collection].items {
+		if other.getID() != r.getID() && other.getName() == r.getName() {
+			errs["name"] = append(errs["name"], "has already been taken")
+		}
+	}
+
+	if h, ok := r.(*Host); ok {
+		refs := []struct {
+			field      string
+			collection string
+			id         int
+		}{
+			{"hostgroup_id", "hostgroups", h.HostgroupID},
+			{"compute_profile_id", "compute_profiles", h.ComputeProfileID},
+			{"organization_id", "organizations", h.OrganizationID},
+			{"location_id", "locations", h.LocationID},
+		}
+		for _, ref := range refs {
+			if ref.id != 0 && !s.exists(ref.collection, ref.id) {
+				errs[ref.field] = append(errs[ref.field], "is invalid")
+			}
+		}
+	}
+
+	return errs
+}
+
+// link fills in the names of the records a host refers to, the lock must be held
+func (s *Server) link(r record) {
+
+	h, ok := r.(*Host)
+	if !ok {
+		return
+	}
+
+	name := func(collection string, id int) string {
+		if other, ok := s.resources[collection].items[id]; ok {
+			return other.getName()
+		}
+		return ""
+	}
+	h.HostgroupName = name("hostgroups", h.HostgroupID)
+	h.ComputeProfileName = name("compute_profiles", h.ComputeProfileID)
+	h.OrganizationName = name("organizations", h.OrganizationID)
+	h.LocationName = name("locations", h.LocationID)
+}
+
+// decode reads the record wrapped in its singular name from the request body
+func decode(rw http.ResponseWriter, req *http.Request, singular string, r record) bool {
+
+	var body map[string]json.RawMessage
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err == nil {
+		if data, ok := body[singular]; ok {
+			err = json.Unmarshal(data, r)
+		} else {
+			err = fmt.Errorf("param is missing or the value is empty: %s", singular)
+		}
+	}
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	return true
+}
+
+// matches reports whether the record satisfies a scoped search such as name ~ dev and hostgroup_id = 1,
+// and binds tighter than or
+func matches(r record, search string) bool {
+
+	search = strings.TrimSpace(search)
+	if search == "" {
+		return true
+	}
+
+	data, _ := json.Marshal(r)
+	var fields map[string]interface{}
+	_ = json.Unmarshal(data, &fields)
+
+	for _, alternative := range strings.Split(search, " or ") {
+		if matchesAll(fields, alternative) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesAll reports whether the fields satisfy every term of the search joined with and
+func matchesAll(fields map[string]interface{}, search string) bool {
+
+	for _, term := range strings.Split(search, " and ") {
+		term = strings.TrimSpace(term)
+
+		var field, op, value string
+		for _, o := range []string{"!=", "=", "~"} {
+			if i := strings.Index(term, o); i > 0 {
+				field, op, value = strings.TrimSpace(term[:i]), o, strings.TrimSpace(term[i+len(o):])
+				break
+			}
+		}
+		if op == "" {
+			field, op, value = "name", "~", term
+		}
+		value = strings.Trim(value, `"`)
+
+		actual, ok := fields[field]
+		if !ok {
+			return false
+		}
+		text := fmt.Sprint(actual)
+
+		switch op {
+		case "=":
+			if text != value {
+				return false
+			}
+		case "!=":
+			if text == value {
+				return false
+			}
+		case "~":
+			if !strings.Contains(strings.ToLower(text), strings.ToLower(value)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// writeJSON writes v with the status code provided
+func writeJSON(rw http.ResponseWriter, status int, v interface{}) {
+	rw.Header().Set("Content-Type", "application/json; charset=utf-8")
+	rw.WriteHeader(status)
+	_ = json.NewEncoder(rw).Encode(v)
+}
+
+// writeError writes an error body in the format foreman uses
+func writeError(rw http.ResponseWriter, status int, message string) {
+	writeJSON(rw, status, map[string]interface{}{"error": map[string]string{"message": message}})
+}
+
+// writeValidationError writes a 422 with the validation errors in the format foreman uses
+func writeValidationError(rw http.ResponseWriter, errs map[string][]string) {
+
+	fields := make([]string, 0, len(errs))
+	for field := range errs {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var full []string
+	for _, field := range fields {
+		label := strings.Replace(strings.TrimSuffix(field, "_id"), "_", " ", -1)
+		label = strings.ToUpper(label[:1]) + label[1:]
+		for _, msg := range errs[field] {
+			full = append(full, label+" "+msg)
+		}
+	}
+
+	writeJSON(rw, http.StatusUnprocessableEntity, map[string]interface{}{
+		"error": map[string]interface{}{"id": nil, "errors": errs, "full_messages": full},
+	})
+}
+
+// nilIfEmpty returns nil for an empty string so it is encoded as null
+func nilIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
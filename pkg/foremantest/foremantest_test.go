@@ -0,0 +1,192 @@
+package foremantest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bishy999/go-foreman/pkg/foreman"
+	"github.com/bishy999/go-foreman/pkg/foremantest"
+)
+
+const (
+	foremantestTimeout = 180
+)
+
+func ExampleNewServer() {
+
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	server.AddOrganization(foremantest.Organization{ID: 9, Name: "engineering"})
+	server.AddLocation(foremantest.Location{ID: 15, Name: "dublin"})
+	hg := server.AddHostgroup(foremantest.Hostgroup{Name: "web"})
+	cp := server.AddComputeProfile(foremantest.ComputeProfile{Name: "small"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), foremantestTimeout*time.Second)
+	defer cancel()
+
+	api := server.ConnectionInfo()
+	api.Hostname = "dev01"
+	api.Group = hg.ID
+	api.Profile = fmt.Sprint(cp.ID)
+
+	_, _, err := api.CreateHost(ctx)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	host, _ := server.Host("dev01")
+	fmt.Println(host.Name, host.HostgroupName, host.ComputeProfileName, host.OrganizationName, host.LocationName, host.Build)
+
+	// Output: dev01 web small engineering dublin true
+}
+
+func TestServerHosts(t *testing.T) {
+
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	server.AddOrganization(foremantest.Organization{ID: 9, Name: "engineering"})
+	server.AddLocation(foremantest.Location{ID: 15, Name: "dublin"})
+	hg := server.AddHostgroup(foremantest.Hostgroup{Name: "web"})
+	server.AddHost(foremantest.Host{Name: "dev01", HostgroupID: hg.ID})
+	server.AddHost(foremantest.Host{Name: "dev02"})
+	server.AddHost(foremantest.Host{Name: "prod01", HostgroupID: hg.ID})
+
+	tt := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		status   int
+		contains string
+	}{
+		{name: "Get by name", method: http.MethodGet, path: "/api/hosts/dev01", status: http.StatusOK, contains: `"name":"dev01"`},
+		{name: "Get missing", method: http.MethodGet, path: "/api/hosts/missing", status: http.StatusNotFound, contains: "Resource host not found by id 'missing'"},
+		{name: "Search", method: http.MethodGet, path: "/api/hosts?search=name+~+dev", status: http.StatusOK, contains: `"subtotal":2`},
+		{name: "Search scoped", method: http.MethodGet, path: fmt.Sprintf("/api/hosts?search=hostgroup_id+%%3D+%d+and+name+~+prod", hg.ID), status: http.StatusOK, contains: `"subtotal":1`},
+		{name: "Paginate", method: http.MethodGet, path: "/api/hosts?per_page=1&page=3", status: http.StatusOK, contains: `"name":"prod01"`},
+		{name: "Create duplicate", method: http.MethodPost, path: "/api/hosts", body: `{"host":{"name":"dev01"}}`, status: http.StatusUnprocessableEntity, contains: "Name has already been taken"},
+		{name: "Create invalid hostgroup", method: http.MethodPost, path: "/api/hosts", body: `{"host":{"name":"dev03","hostgroup_id":99}}`, status: http.StatusUnprocessableEntity, contains: "Hostgroup is invalid"},
+		{name: "Create missing wrapper", method: http.MethodPost, path: "/api/hosts", body: `{"name":"dev03"}`, status: http.StatusBadRequest, contains: "param is missing"},
+		{name: "Create", method: http.MethodPost, path: "/api/hosts", body: `{"host":{"name":"dev03","compute_profile_id":""}}`, status: http.StatusCreated, contains: `"enabled":true`},
+		{name: "Update", method: http.MethodPut, path: "/api/hosts/dev02", body: `{"host":{"build":true}}`, status: http.StatusOK, contains: `"build":true`},
+		{name: "Delete", method: http.MethodDelete, path: "/api/hosts/dev02", status: http.StatusOK, contains: `"name":"dev02"`},
+		{name: "Deleted", method: http.MethodGet, path: "/api/hosts/dev02", status: http.StatusNotFound, contains: "Resource host not found"},
+		{name: "Unknown route", method: http.MethodGet, path: "/api/widgets", status: http.StatusNotFound, contains: "not found"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+
+			req, err := http.NewRequest(tc.method, server.URL+tc.path, bytes.NewBufferString(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.SetBasicAuth(foremantest.Username, foremantest.Password)
+
+			resp, err := server.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			body, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.StatusCode != tc.status {
+				t.Errorf("expected status %d got %d: %s", tc.status, resp.StatusCode, body)
+			}
+			if !strings.Contains(string(body), tc.contains) {
+				t.Errorf("expected body to contain %q got %s", tc.contains, body)
+			}
+			if !json.Valid(body) {
+				t.Errorf("expected json body got %s", body)
+			}
+		})
+	}
+
+	if got := len(server.Hosts()); got != 3 {
+		t.Errorf("expected 3 hosts got %d", got)
+	}
+}
+
+func TestServerClient(t *testing.T) {
+
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	server.AddOrganization(foremantest.Organization{Name: "engineering"})
+	server.AddLocation(foremantest.Location{Name: "dublin"})
+	hg := server.AddHostgroup(foremantest.Hostgroup{Name: "web"})
+	server.AddHost(foremantest.Host{Name: "dev01"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), foremantestTimeout*time.Second)
+	defer cancel()
+
+	api := server.ConnectionInfo()
+
+	t.Run("Status", func(t *testing.T) {
+		_, status, err := api.CheckStatus(ctx)
+		if err != nil || !strings.Contains(status, `"result":"ok"`) {
+			t.Errorf("unexpected status %s %v", status, err)
+		}
+	})
+
+	t.Run("Check missing host", func(t *testing.T) {
+		missing := api
+		missing.Hostname = "missing"
+		exists, _, err := missing.CheckHost(ctx)
+		if err != nil || exists {
+			t.Errorf("expected host not to exist got %t %v", exists, err)
+		}
+	})
+
+	t.Run("Resolve hostgroup", func(t *testing.T) {
+		id, err := api.ResolveHostgroup(ctx, "web")
+		if err != nil || id != hg.ID {
+			t.Errorf("expected %d got %d %v", hg.ID, id, err)
+		}
+	})
+
+	t.Run("List taxonomies", func(t *testing.T) {
+		orgs, err := api.ListOrganizations(ctx, "")
+		if err != nil || len(orgs) != 1 || orgs[0].Title != "engineering" {
+			t.Errorf("unexpected organizations %+v %v", orgs, err)
+		}
+		locs, err := api.ListLocations(ctx, "name = dublin")
+		if err != nil || len(locs) != 1 {
+			t.Errorf("unexpected locations %+v %v", locs, err)
+		}
+	})
+
+	t.Run("Delete host", func(t *testing.T) {
+		host := api
+		host.Hostname = "dev01"
+		_, _, err := host.DeleteHost(ctx)
+		if _, ok := server.Host("dev01"); err != nil || ok {
+			t.Errorf("expected host to be deleted %v", err)
+		}
+	})
+
+	t.Run("Bad credentials", func(t *testing.T) {
+		bad := api
+		bad.Password = "wrong"
+		_, err := bad.ListOrganizations(ctx, "")
+		var apiErr *foreman.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+			t.Errorf("expected 401 got %v", err)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package foremantest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Host is a host stored by the server
+type Host struct {
+	ID                 int    `json:"id"`
+	Name               string `json:"name"`
+	HostgroupID        int    `json:"hostgroup_id,omitempty"`
+	HostgroupName      string `json:"hostgroup_name,omitempty"`
+	ComputeProfileID   int    `json:"compute_profile_id,omitempty"`
+	ComputeProfileName string `json:"compute_profile_name,omitempty"`
+	OrganizationID     int    `json:"organization_id,omitempty"`
+	OrganizationName   string `json:"organization_name,omitempty"`
+	LocationID         int    `json:"location_id,omitempty"`
+	LocationName       string `json:"location_name,omitempty"`
+	IP                 string `json:"ip,omitempty"`
+	MAC                string `json:"mac,omitempty"`
+	Comment            string `json:"comment,omitempty"`
+	Build              bool   `json:"build"`
+	Enabled            bool   `json:"enabled"`
+	Managed            bool   `json:"managed"`
+	CreatedAt          string `json:"created_at"`
+	UpdatedAt          string `json:"updated_at"`
+}
+
+// UnmarshalJSON accepts the compute profile id as a number or a string, as foreman does
+func (h *Host) UnmarshalJSON(data []byte) error {
+
+	type host Host
+	aux := struct {
+		*host
+		ComputeProfileID interface{} `json:"compute_profile_id"`
+	}{host: (*host)(h)}
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	switch id := aux.ComputeProfileID.(type) {
+	case nil:
+	case float64:
+		h.ComputeProfileID = int(id)
+	case string:
+		h.ComputeProfileID, err = strconv.Atoi(id)
+		if err != nil && id != "" {
+			return fmt.Errorf("invalid compute_profile_id %q", id)
+		}
+		err = nil
+	default:
+		return fmt.Errorf("invalid compute_profile_id %v", id)
+	}
+
+	return err
+}
+
+func (h *Host) getID() int      { return h.ID }
+func (h *Host) setID(id int)    { h.ID = id }
+func (h *Host) getName() string { return h.Name }
+func (h *Host) touch(now string) {
+	if h.CreatedAt == "" {
+		h.CreatedAt = now
+	}
+	h.UpdatedAt = now
+}
+
+// Hostgroup is a hostgroup stored by the server
+type Hostgroup struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Title     string `json:"title"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func (hg *Hostgroup) getID() int      { return hg.ID }
+func (hg *Hostgroup) setID(id int)    { hg.ID = id }
+func (hg *Hostgroup) getName() string { return hg.Name }
+func (hg *Hostgroup) touch(now string) {
+	if hg.Title == "" {
+		hg.Title = hg.Name
+	}
+	if hg.CreatedAt == "" {
+		hg.CreatedAt = now
+	}
+	hg.UpdatedAt = now
+}
+
+// ComputeProfile is a compute profile stored by the server
+type ComputeProfile struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func (cp *ComputeProfile) getID() int      { return cp.ID }
+func (cp *ComputeProfile) setID(id int)    { cp.ID = id }
+func (cp *ComputeProfile) getName() string { return cp.Name }
+func (cp *ComputeProfile) touch(now string) {
+	if cp.CreatedAt == "" {
+		cp.CreatedAt = now
+	}
+	cp.UpdatedAt = now
+}
+
+// Organization is an organization stored by the server
+type Organization struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+func (o *Organization) getID() int      { return o.ID }
+func (o *Organization) setID(id int)    { o.ID = id }
+func (o *Organization) getName() string { return o.Name }
+func (o *Organization) touch(now string) {
+	if o.Title == "" {
+		o.Title = o.Name
+	}
+	if o.CreatedAt == "" {
+		o.CreatedAt = now
+	}
+	o.UpdatedAt = now
+}
+
+// Location is a location stored by the server
+type Location struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+func (l *Location) getID() int      { return l.ID }
+func (l *Location) setID(id int)    { l.ID = id }
+func (l *Location) getName() string { return l.Name }
+func (l *Location) touch(now string) {
+	if l.Title == "" {
+		l.Title = l.Name
+	}
+	if l.CreatedAt == "" {
+		l.CreatedAt = now
+	}
+	l.UpdatedAt = now
+}